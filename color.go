@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+func validateColorMode(mode string) error {
+	switch mode {
+	case colorAuto, colorAlways, colorNever:
+		return nil
+	}
+	return fmt.Errorf("Unknown color mode %q, expected auto, always or never", mode)
+}
+
+// useColor decides whether output written to f should be colorized.
+// In auto mode colors are used only for terminals and when NO_COLOR
+// (see https://no-color.org) is not set.
+func useColor(mode string, f *os.File) bool {
+	switch mode {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(f)
+}
+
+// isTerminal returns true if f is a terminal (not just any character device, such as /dev/null)
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
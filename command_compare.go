@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/govendor"
+	"github.com/radeksimko/go-mod-diff/policy"
+	"github.com/radeksimko/go-mod-diff/report"
+)
+
+var reportWriters = map[string]func(io.Writer, *report.Report) error{
+	"json":     report.WriteJSON,
+	"markdown": report.WriteMarkdown,
+	"html":     report.WriteHTML,
+	"junit":    report.WriteJUnit,
+}
+
+var failOnCategories = map[string]func(*report.Report) int{
+	"different": func(r *report.Report) int { return len(r.Diff.Different) },
+	"notfound":  func(r *report.Report) int { return len(r.Diff.NotFound) },
+	"errored":   func(r *report.Report) int { return len(r.Diff.Errored) },
+	"stale":     func(r *report.Report) int { return len(r.StaleAcceptances) },
+}
+
+func compareCommand(args []string) int {
+	fs := newFlagSet("compare", "[options] <vendor.json>")
+	var cf commonFlags
+	cf.addFlags(fs)
+	inputFormat := fs.String("input-format", "govendor", "Format of the dependency file to compare with (govendor)")
+	suggestTags := fs.Bool("suggest-tags", false,
+		"Suggest nearest semver tags for pseudo-version requirements (uses many GitHub API calls)")
+	compareCommits := fs.Bool("compare-commits", false,
+		"Compare multiple govendor revisions of a module via GitHub to count commits between them (uses GitHub API calls)")
+	format := fs.String("format", "text", "Output format (text, json, markdown, html or junit)")
+	outputPath := fs.String("o", "", "Write output to given file instead of stdout")
+	granularity := fs.String("granularity", string(report.GranularityModule),
+		"Granularity of the report (module, or package to list every vendored package with its revision)")
+	failOn := fs.String("fail-on", "different,notfound,errored",
+		"Comma-separated categories which cause exit code 1 when not empty (different, notfound, errored, stale or none)")
+	policyPath := fs.String("policy", "",
+		"Path to policy file with accepted differences (defaults to "+policy.DefaultFilename+" next to go.mod if it exists)")
+	govendorWhy := fs.Bool("govendor-why", false,
+		"Explain why govendor needed different packages by scanning imports of the project and its vendor tree "+
+			"(expects vendor.json in the vendor directory)")
+	if code, ok := parseFlags(fs, args, &cf); !ok {
+		return code
+	}
+
+	if *inputFormat != "govendor" {
+		return cf.fail(fmt.Errorf("Unknown input format %q, expected govendor", *inputFormat))
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return cf.fail(fmt.Errorf("Expected exactly 1 argument (path to vendor.json), %d given", fs.NArg()))
+	}
+
+	writeReport, ok := reportWriters[*format]
+	if *format == "text" {
+		color := cf.color(os.Stdout) && *outputPath == ""
+		writeReport = func(w io.Writer, r *report.Report) error {
+			tw := report.NewTextWriter(w, color)
+			tw.SetDiagnostics(os.Stderr, cf.color(os.Stderr))
+			return tw.WriteReport(r)
+		}
+	} else if !ok {
+		return cf.fail(fmt.Errorf("Unknown output format %q, expected text, json, markdown, html or junit", *format))
+	}
+
+	switch report.Granularity(*granularity) {
+	case report.GranularityModule:
+	case report.GranularityPackage:
+		if *format != "text" && *format != "json" {
+			return cf.fail(fmt.Errorf("Package granularity is only supported by text and json output formats"))
+		}
+	default:
+		return cf.fail(fmt.Errorf("Unknown granularity %q, expected module or package", *granularity))
+	}
+
+	failOnCounts, err := parseFailOn(*failOn)
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	gh := cf.github()
+
+	goModFile, err := cf.parseModFile()
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	pol, err := loadPolicy(*policyPath, cf.modFilePath)
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	govendorFile, err := govendor.ParseFile(fs.Arg(0))
+	if err != nil {
+		return cf.fail(fmt.Errorf("Failed to parse govendor file: %s", err))
+	}
+
+	// Compare both and print out differences
+	d, err := diff.CompareGoModWithGovendor(goModFile, govendorFile, gh)
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	var stale []*policy.StaleAcceptance
+	if pol != nil {
+		stale = pol.Apply(d)
+	}
+
+	if *compareCommits {
+		diff.ReconcileVersions(d, gh)
+	} else {
+		diff.ReconcileVersions(d, nil)
+	}
+	if *suggestTags {
+		diff.SuggestTags(d, gh)
+	}
+
+	r := report.New(d, goModFile)
+	r.Granularity = report.Granularity(*granularity)
+	if stale != nil {
+		r.StaleAcceptances = stale
+	}
+	if *govendorWhy {
+		if govendorFile.RootPath == "" {
+			return cf.fail(fmt.Errorf("Unable to scan imports, rootPath of the project is missing in %s", fs.Arg(0)))
+		}
+		projectDir := filepath.Dir(filepath.Dir(fs.Arg(0)))
+		graph, err := govendor.LoadImportGraph(projectDir, govendorFile.RootPath)
+		if err != nil {
+			return cf.fail(fmt.Errorf("Failed to scan imports: %s", err))
+		}
+		r.GovendorWhy = report.GovendorWhy(graph, d)
+	}
+
+	err = writeReportTo(*outputPath, writeReport, r)
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	for _, count := range failOnCounts {
+		if count(r) > 0 {
+			return exitDifferent
+		}
+	}
+	return exitOK
+}
+
+// loadPolicy parses policy file at path, or the default one next to go.mod
+// if path is empty. It returns nil if there is no policy to apply.
+func loadPolicy(path, modFilePath string) (*policy.Policy, error) {
+	defaultPath := filepath.Join(filepath.Dir(modFilePath), policy.DefaultFilename)
+	if path == "" && fileExists(defaultPath) {
+		path = defaultPath
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	pol, err := policy.ParseFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse policy: %s", err)
+	}
+	return pol, nil
+}
+
+func parseFailOn(value string) ([]func(*report.Report) int, error) {
+	funcs := make([]func(*report.Report) int, 0)
+	if value == "none" || value == "" {
+		return funcs, nil
+	}
+
+	for _, name := range strings.Split(value, ",") {
+		f, ok := failOnCategories[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("Unknown -fail-on category %q, expected different, notfound, errored, stale or none", name)
+		}
+		funcs = append(funcs, f)
+	}
+	return funcs, nil
+}
+
+func writeReportTo(path string, writeReport func(io.Writer, *report.Report) error, r *report.Report) error {
+	return writeOutputTo(path, func(w io.Writer) error {
+		return writeReport(w, r)
+	})
+}
+
+// writeOutputTo calls write with the file at path, or stdout if path is empty
+func writeOutputTo(path string, write func(io.Writer) error) error {
+	if path == "" {
+		return write(os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = write(f)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/fix"
+	"github.com/radeksimko/go-mod-diff/govendor"
+	"github.com/radeksimko/go-mod-diff/policy"
+	"github.com/radeksimko/go-mod-diff/report"
+)
+
+func fixCommand(args []string) int {
+	fs := newFlagSet("fix", "[options] <vendor.json>")
+	var cf commonFlags
+	cf.addFlags(fs)
+	dryRun := fs.Bool("dry-run", false, "Print unified diff of go.mod instead of writing it")
+	script := fs.Bool("script", false,
+		"Print shell script of go mod edit commands instead of writing go.mod")
+	outputPath := fs.String("o", "", "Write diff or script to given file instead of stdout")
+	interactiveMode := fs.Bool("interactive", false,
+		"Ask how to resolve each difference (keep, pin to govendor revision, pin to tag or skip)")
+	suggestTags := fs.Bool("suggest-tags", false,
+		"Suggest nearest semver tags as defaults in interactive mode (uses many GitHub API calls)")
+	compareCommits := fs.Bool("compare-commits", false,
+		"Compare multiple govendor revisions of a module via GitHub in interactive mode (uses GitHub API calls)")
+	policyPath := fs.String("policy", "",
+		"Path to policy file with accepted differences, which are left unchanged (defaults to "+
+			policy.DefaultFilename+" next to go.mod if it exists)")
+	if code, ok := parseFlags(fs, args, &cf); !ok {
+		return code
+	}
+
+	if *dryRun && *script {
+		return cf.fail(fmt.Errorf("-dry-run and -script cannot be used together"))
+	}
+	if *outputPath != "" && !*dryRun && !*script {
+		return cf.fail(fmt.Errorf("-o can only be used with -dry-run or -script"))
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return cf.fail(fmt.Errorf("Expected exactly 1 argument (path to vendor.json), %d given", fs.NArg()))
+	}
+
+	original, err := ioutil.ReadFile(cf.modFilePath)
+	if err != nil {
+		return cf.fail(fmt.Errorf("Failed to read go.mod: %s", err))
+	}
+	goModFile, err := cf.parseModFile()
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	pol, err := loadPolicy(*policyPath, cf.modFilePath)
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	govendorFile, err := govendor.ParseFile(fs.Arg(0))
+	if err != nil {
+		return cf.fail(fmt.Errorf("Failed to parse govendor file: %s", err))
+	}
+
+	gh := cf.github()
+	d, err := diff.CompareGoModWithGovendor(goModFile, govendorFile, gh)
+	if err != nil {
+		return cf.fail(err)
+	}
+	if pol != nil {
+		pol.Apply(d)
+	}
+
+	var plan *fix.Plan
+	var ia *interactive
+	if *interactiveMode {
+		if *compareCommits {
+			diff.ReconcileVersions(d, gh)
+		} else {
+			diff.ReconcileVersions(d, nil)
+		}
+		if *suggestTags {
+			diff.SuggestTags(d, gh)
+		}
+
+		// Prompts go to stderr, so that stdout can be used for diff or script
+		ia = newInteractive(os.Stdin, os.Stderr, cf.color(os.Stderr), gh)
+		plan, err = ia.Plan(report.New(d, goModFile))
+		if err != nil {
+			return cf.fail(err)
+		}
+	} else {
+		plan = fix.NewPlan(d)
+	}
+
+	fixed, err := plan.Apply(goModFile)
+	if err != nil {
+		return cf.fail(fmt.Errorf("Failed to update go.mod: %s", err))
+	}
+
+	if *script {
+		err = writeOutputTo(*outputPath, func(w io.Writer) error {
+			return plan.WriteScript(w, cf.modFilePath)
+		})
+		if err != nil {
+			return cf.fail(err)
+		}
+		return exitOK
+	}
+
+	c := report.NewColorize(cf.color(os.Stdout))
+	errC := report.NewColorize(cf.color(os.Stderr))
+	for _, s := range plan.Skipped {
+		fmt.Fprintf(os.Stderr, errC.Color("[bold][yellow]Skipped[reset] [bold]%s[reset]: %s\n"),
+			s.ModulePath, s.Reason)
+	}
+
+	if *dryRun {
+		err = writeOutputTo(*outputPath, func(w io.Writer) error {
+			_, err := io.WriteString(w, fix.UnifiedDiff(cf.modFilePath, original, fixed))
+			return err
+		})
+		if err != nil {
+			return cf.fail(err)
+		}
+		return exitOK
+	}
+
+	if len(plan.Changes) == 0 {
+		fmt.Println("Nothing to fix.")
+		return exitOK
+	}
+
+	if ia != nil {
+		ia.printf("\n")
+		for _, change := range plan.Changes {
+			ia.printf(" - %s\n", change.String())
+		}
+		answer, err := ia.prompt(fmt.Sprintf("Apply %d changes to %s? (y/n)", len(plan.Changes), cf.modFilePath), "n")
+		if err != nil {
+			return cf.fail(err)
+		}
+		if answer != "y" && answer != "yes" {
+			return cf.fail(errInteractiveQuit)
+		}
+	}
+
+	fi, err := os.Stat(cf.modFilePath)
+	if err != nil {
+		return cf.fail(err)
+	}
+	err = ioutil.WriteFile(cf.modFilePath, fixed, fi.Mode())
+	if err != nil {
+		return cf.fail(fmt.Errorf("Failed to write go.mod: %s", err))
+	}
+
+	for _, change := range plan.Changes {
+		fmt.Printf(c.Color("Pinned [bold]%s[reset] from %s to [bold][cyan]%s[reset]\n"),
+			change.ModulePath, change.From, change.To)
+	}
+	return exitOK
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/gomod"
+	"github.com/radeksimko/go-mod-diff/govendor"
+	"github.com/radeksimko/go-mod-diff/report"
+)
+
+func graphCommand(args []string) int {
+	fs := newFlagSet("graph", "[options] <module> [module...]")
+	var cf commonFlags
+	cf.addFlags(fs)
+	govendorPath := fs.String("govendor", "", "Path to vendor.json to show govendor revisions alongside")
+	if code, ok := parseFlags(fs, args, &cf); !ok {
+		return code
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return cf.fail(fmt.Errorf("Expected at least 1 module path"))
+	}
+
+	goModFile, err := cf.parseModFile()
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	var gvVersions func(string) []*diff.Version
+	if *govendorPath != "" {
+		govendorFile, err := govendor.ParseFile(*govendorPath)
+		if err != nil {
+			return cf.fail(fmt.Errorf("Failed to parse govendor file: %s", err))
+		}
+		required := gomod.RequiredPaths(goModFile)
+		gvVersions = func(path string) []*diff.Version {
+			return diff.GovendorVersions(govendor.PackagesForModule(govendorFile.Package, required, path), path)
+		}
+	}
+
+	graph, stderr, err := gomod.LoadModuleGraph(filepath.Dir(cf.modFilePath))
+	if err != nil {
+		return cf.fail(fmt.Errorf("Failed to run go mod graph: %s\n%s", err, stderr))
+	}
+
+	c := report.NewColorize(cf.color(os.Stdout))
+	vlF := gomod.GetVersionForModule(goModFile)
+	for _, path := range fs.Args() {
+		fmt.Printf(c.Color("\n[bold]%s[reset]"), path)
+		if version := vlF(path); version != "" {
+			fmt.Printf(" @ %s", version)
+		}
+		fmt.Print("\n")
+
+		var govendorVersions []*diff.Version
+		if gvVersions != nil {
+			fmt.Print(" - govendor: ")
+			versions := gvVersions(path)
+			govendorVersions = versions
+			if len(versions) == 0 {
+				fmt.Print(c.Color("[red]not found\n"))
+			}
+			for i, v := range versions {
+				if i > 0 {
+					fmt.Print("             ")
+				}
+				fmt.Println(v.String())
+			}
+		}
+
+		versions := graph.Versions(path)
+		if len(versions) == 0 {
+			fmt.Print(c.Color(" - [red]not required by any module (try `go mod tidy`)\n"))
+			continue
+		}
+
+		selected := graph.SelectedVersion(path)
+		requirements := graph.Requirements(path)
+		for _, v := range versions {
+			if v == selected {
+				fmt.Printf(c.Color(" - [bold][cyan]%s[reset] (selected)"), v)
+				if govendorVersions != nil && !matchesAnyVersion(v, govendorVersions) {
+					fmt.Print(c.Color(" [yellow]differs from govendor[reset]"))
+				}
+				fmt.Print(", required by:\n")
+			} else {
+				fmt.Printf(" - %s, required by:\n", v)
+			}
+			for _, r := range requirements[v] {
+				if v == selected {
+					fmt.Printf(c.Color("     [bold]%s[reset]\n"), r.Dependent())
+				} else {
+					fmt.Printf("     %s\n", r.Dependent())
+				}
+			}
+		}
+
+		// Explain when dependencies forced a version higher than go.mod itself requires
+		if mr := graph.MainRequirement(path); mr != nil && mr.Version != selected {
+			for _, r := range requirements[selected] {
+				fmt.Printf(c.Color(" - [yellow]main module requires %s, %s raises it to %s[reset]\n"),
+					mr.Version, r.Dependent(), selected)
+			}
+		}
+	}
+
+	return exitOK
+}
+
+func matchesAnyVersion(version string, versions []*diff.Version) bool {
+	v := &diff.Version{Version: version}
+	if ref, err := gomod.ParseRefFromVersion(version); err == nil && ref.IsRevision() {
+		v.Revision = ref.String()
+	}
+	for _, gv := range versions {
+		if gv.IsEqual(v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/github"
+	"github.com/radeksimko/go-mod-diff/gomod"
+	"github.com/radeksimko/go-mod-diff/report"
+)
+
+func resolveCommand(args []string) int {
+	fs := newFlagSet("resolve", "[options] <module>[@version] [module[@version]...]")
+	var cf commonFlags
+	cf.addFlags(fs)
+	suggestTags := fs.Bool("suggest-tags", false,
+		"Suggest nearest semver tags for pseudo-versions (uses many GitHub API calls)")
+	if code, ok := parseFlags(fs, args, &cf); !ok {
+		return code
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return cf.fail(fmt.Errorf("Expected at least 1 module path"))
+	}
+
+	var vlF gomod.VersionLookupFunc
+	gh := cf.github()
+	c := report.NewColorize(cf.color(os.Stdout))
+	errC := report.NewColorize(cf.color(os.Stderr))
+
+	exitCode := exitOK
+	for _, arg := range fs.Args() {
+		path, version := arg, ""
+		if i := strings.Index(arg, "@"); i != -1 {
+			path, version = arg[:i], arg[i+1:]
+		}
+
+		if version == "" {
+			if vlF == nil {
+				goModFile, err := cf.parseModFile()
+				if err != nil {
+					return cf.fail(err)
+				}
+				vlF = gomod.GetVersionForModule(goModFile)
+			}
+			version = vlF(path)
+		}
+
+		fmt.Printf(c.Color("\n[bold]%s[reset] @ %s\n"), path, version)
+		err := resolve(gh, path, version, *suggestTags)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, errC.Color("[bold][red]Error:[reset] %s: [red]%s[reset]\n"), arg, err)
+			exitCode = exitError
+		}
+	}
+
+	return exitCode
+}
+
+func resolve(gh *github.GitHub, path, version string, suggestTags bool) error {
+	if version == "" {
+		return fmt.Errorf("Module not found in go.mod, please specify version as %s@version", path)
+	}
+
+	ref, err := gomod.ParseRefFromVersion(version)
+	if err != nil {
+		return err
+	}
+
+	repo, err := github.ParseRepositoryURL(path)
+	if err != nil {
+		return fmt.Errorf("Module is not hosted on GitHub: %s", err)
+	}
+
+	status, err := gh.GetRepositoryStatus(repo)
+	if err != nil {
+		return fmt.Errorf("Failed to look up repository on GitHub: %s", err)
+	}
+	if status.NotFound {
+		return fmt.Errorf("Repository %s not found on GitHub (deleted or private)", repo)
+	}
+	if *status.Canonical != *repo {
+		fmt.Printf(" - moved to: %s\n", status.Canonical)
+	}
+	if status.Archived {
+		fmt.Printf(" - archived\n")
+	}
+	repo = status.Canonical
+
+	sha, err := gh.GetCommitSHA(repo, ref.String())
+	if err != nil {
+		return fmt.Errorf("Failed to get ref SHA from GitHub: %s", err)
+	}
+	fmt.Printf(" - revision: %s\n", sha)
+	fmt.Printf(" - GitHub: %s\n", github.TreeURL(repo, sha))
+
+	if suggestTags && ref.IsRevision() {
+		ts, err := diff.NearestTags(gh, repo, sha)
+		if err != nil {
+			return fmt.Errorf("Failed to find nearest tags: %s", err)
+		}
+		if s := ts.Suggested(); s != nil {
+			fmt.Printf(" - suggested tag: %s\n", s)
+		} else {
+			fmt.Printf(" - suggested tag: no semver tags found near revision\n")
+		}
+	}
+
+	return nil
+}
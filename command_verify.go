@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mitchellh/colorstring"
+	"github.com/radeksimko/go-mod-diff/gomod"
+	"github.com/radeksimko/go-mod-diff/govendor"
+	"github.com/radeksimko/go-mod-diff/report"
+	"github.com/radeksimko/go-mod-diff/verify"
+	"golang.org/x/mod/module"
+)
+
+func verifyCommand(args []string) int {
+	fs := newFlagSet("verify", "[options] <vendor/vendor.json>")
+	checksums := fs.Bool("checksums", false,
+		"Only validate checksums in vendor.json against the vendor directory (offline, go.mod is not read)")
+	goSum := fs.Bool("gosum", false,
+		"Only verify go.sum hashes of go.mod requirements against the module cache (offline, no vendor.json expected)")
+	var cf commonFlags
+	cf.addFlags(fs)
+	if code, ok := parseFlags(fs, args, &cf); !ok {
+		return code
+	}
+
+	if *checksums && *goSum {
+		fs.Usage()
+		return cf.fail(fmt.Errorf("-checksums and -gosum cannot be used together"))
+	}
+
+	c := report.NewColorize(cf.color(os.Stdout))
+
+	if *goSum {
+		if fs.NArg() != 0 {
+			fs.Usage()
+			return cf.fail(fmt.Errorf("Expected no arguments with -gosum, %d given", fs.NArg()))
+		}
+		return verifyGoSum(c, &cf)
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return cf.fail(fmt.Errorf("Expected exactly 1 argument (path to vendor.json), %d given", fs.NArg()))
+	}
+
+	govendorFile, err := govendor.ParseFile(fs.Arg(0))
+	if err != nil {
+		return cf.fail(fmt.Errorf("Failed to parse govendor file: %s", err))
+	}
+	vendorDir := filepath.Dir(fs.Arg(0))
+
+	if *checksums {
+		r, err := verify.VerifyChecksums(vendorDir, govendorFile)
+		if err != nil {
+			return cf.fail(fmt.Errorf("Failed to verify checksums: %s", err))
+		}
+		writeChecksumReport(c, r)
+		if r.HasProblems() {
+			return exitDifferent
+		}
+		return exitOK
+	}
+
+	goModFile, err := cf.parseModFile()
+	if err != nil {
+		return cf.fail(err)
+	}
+	goModDir := filepath.Dir(cf.modFilePath)
+
+	moduleDirs, err := moduleDirs(goModDir, verify.PackageModules(govendorFile, goModFile))
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	trees := verify.VendorTree(vendorDir, govendorFile, goModFile, moduleDirs)
+	different, errored := 0, 0
+	for _, pt := range trees {
+		if pt.Error != nil {
+			errored++
+			fmt.Printf(c.Color("\n[bold]%s[reset] (%s)\n - [bold][red]Error:[reset] [red]%s[reset]\n"),
+				pt.Package, pt.Module, pt.Error)
+			continue
+		}
+		if len(pt.Differences) == 0 {
+			continue
+		}
+		different++
+		fmt.Printf(c.Color("\n[bold]%s[reset] (%s)\n"), pt.Package, pt.Module)
+		for _, fd := range pt.Differences {
+			fmt.Printf(c.Color(" - [yellow]%s[reset]\n"), fd.String())
+		}
+	}
+
+	fmt.Printf(c.Color("\nVendored packages matching go.mod modules: [bold][green]%d[reset] of %d "+
+		"([bold][yellow]%d[reset] different, [bold][red]%d[reset] failed to verify).\n"),
+		len(trees)-different-errored, len(trees), different, errored)
+
+	if errored > 0 {
+		return exitError
+	}
+	if different > 0 {
+		return exitDifferent
+	}
+	return exitOK
+}
+
+// moduleDirs returns directories with contents of given module versions
+// keyed by path@version, downloading them into the module cache if needed
+func moduleDirs(goModDir string, modules map[string]module.Version) (map[string]string, error) {
+	dirs := make(map[string]string, 0)
+	toDownload := make([]module.Version, 0)
+	seen := make(map[string]bool, 0)
+	for _, mv := range modules {
+		if seen[mv.String()] {
+			continue
+		}
+		seen[mv.String()] = true
+
+		// Replacement with local directory
+		if mv.Version == "" {
+			dir := mv.Path
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(goModDir, dir)
+			}
+			dirs[mv.String()] = dir
+			continue
+		}
+		toDownload = append(toDownload, mv)
+	}
+	if len(toDownload) == 0 {
+		return dirs, nil
+	}
+	sort.Slice(toDownload, func(i, j int) bool {
+		return toDownload[i].String() < toDownload[j].String()
+	})
+
+	downloaded, stderr, err := gomod.DownloadModules(goModDir, toDownload)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to download modules: %s\n%s", err, stderr)
+	}
+	for key, dm := range downloaded {
+		if dm.Error != "" {
+			fmt.Fprintf(os.Stderr, "Failed to download %s: %s\n", key, dm.Error)
+			continue
+		}
+		dirs[key] = dm.Dir
+	}
+	return dirs, nil
+}
+
+func writeChecksumReport(c *colorstring.Colorize, r *verify.ChecksumReport) {
+	for _, pc := range r.Mismatched {
+		fmt.Printf(c.Color("\n[bold]%s[reset]\n - [yellow]checksum mismatch:[reset] vendor.json %s, vendor %s\n"),
+			pc.Package, pc.Expected, pc.Actual)
+	}
+	writeSection(c, "[bold][red]Missing in vendor directory:[reset]", r.Missing)
+	writeSection(c, "[bold][yellow]No checksum in vendor.json:[reset]", r.Unrecorded)
+	writeSection(c, "[bold][yellow]Not in vendor.json (orphaned):[reset]", r.Orphaned)
+
+	fmt.Printf(c.Color("\nPackages matching vendor.json checksums: [bold][green]%d[reset] "+
+		"([bold][yellow]%d[reset] mismatched, [bold][red]%d[reset] missing, %d without checksum, %d orphaned directories).\n"),
+		r.Verified, len(r.Mismatched), len(r.Missing), len(r.Unrecorded), len(r.Orphaned))
+}
+
+func writeSection(c *colorstring.Colorize, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Print(c.Color("\n" + title + "\n"))
+	for _, l := range lines {
+		fmt.Printf(" - %s\n", l)
+	}
+}
+
+func verifyGoSum(c *colorstring.Colorize, cf *commonFlags) int {
+	goModFile, err := cf.parseModFile()
+	if err != nil {
+		return cf.fail(err)
+	}
+	goSumPath := filepath.Join(filepath.Dir(cf.modFilePath), "go.sum")
+	sums, err := gomod.ParseSumFile(goSumPath)
+	if err != nil {
+		return cf.fail(fmt.Errorf("Failed to parse go.sum: %s", err))
+	}
+	cache, err := gomod.FindModuleCache()
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	r, err := verify.VerifyGoSum(goModFile, sums, cache)
+	if err != nil {
+		return cf.fail(err)
+	}
+
+	titles := []struct {
+		status verify.SumStatus
+		title  string
+	}{
+		{verify.SumMismatch, "[bold][red]Mismatched hashes:[reset]"},
+		{verify.SumMissing, "[bold][red]Missing in go.sum:[reset]"},
+		{verify.SumExtra, "[bold][yellow]Extra in go.sum (other versions of required modules):[reset]"},
+		{verify.SumUnavailable, "[bold][yellow]Not in module cache (run go mod download to verify):[reset]"},
+	}
+	counts := make(map[verify.SumStatus]int, 0)
+	for _, t := range titles {
+		lines := make([]string, 0)
+		for _, sd := range r.Differences {
+			if sd.Status == t.status {
+				lines = append(lines, sd.String())
+			}
+		}
+		counts[t.status] = len(lines)
+		writeSection(c, t.title, lines)
+	}
+
+	fmt.Printf(c.Color("\ngo.sum hashes matching module cache: [bold][green]%d[reset] "+
+		"([bold][red]%d[reset] mismatched, [bold][red]%d[reset] missing, %d extra, %d not in module cache).\n"),
+		r.Verified, counts[verify.SumMismatch], counts[verify.SumMissing],
+		counts[verify.SumExtra], counts[verify.SumUnavailable])
+
+	return goSumExitCode(r)
+}
+
+// goSumExitCode fails on mismatched and missing hashes only,
+// extra hashes and modules missing in the module cache are informational
+func goSumExitCode(r *verify.SumReport) int {
+	for _, sd := range r.Differences {
+		if sd.Status == verify.SumMismatch || sd.Status == verify.SumMissing {
+			return exitDifferent
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/verify"
+)
+
+func TestGoSumExitCode(t *testing.T) {
+	testCases := []struct {
+		statuses []verify.SumStatus
+		expected int
+	}{
+		{nil, exitOK},
+		{[]verify.SumStatus{verify.SumUnavailable, verify.SumUnavailable}, exitOK},
+		{[]verify.SumStatus{verify.SumExtra, verify.SumUnavailable}, exitOK},
+		{[]verify.SumStatus{verify.SumUnavailable, verify.SumMismatch}, exitDifferent},
+		{[]verify.SumStatus{verify.SumExtra, verify.SumMissing}, exitDifferent},
+	}
+
+	for i, tc := range testCases {
+		r := &verify.SumReport{}
+		for _, status := range tc.statuses {
+			r.Differences = append(r.Differences, &verify.SumDifference{Status: status})
+		}
+		if code := goSumExitCode(r); code != tc.expected {
+			t.Fatalf("%d: Expected exit code %d, given: %d", i, tc.expected, code)
+		}
+	}
+}
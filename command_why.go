@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/radeksimko/go-mod-diff/gomod"
+	"github.com/radeksimko/go-mod-diff/report"
+)
+
+func whyCommand(args []string) int {
+	fs := newFlagSet("why", "[options] <module> [module...]")
+	var cf commonFlags
+	cf.addFlags(fs)
+	if code, ok := parseFlags(fs, args, &cf); !ok {
+		return code
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return cf.fail(fmt.Errorf("Expected at least 1 module path"))
+	}
+
+	goModFile, err := cf.parseModFile()
+	if err != nil {
+		return cf.fail(err)
+	}
+	vlF := gomod.GetVersionForModule(goModFile)
+
+	c := report.NewColorize(cf.color(os.Stdout))
+	tw := report.NewTextWriter(os.Stdout, cf.color(os.Stdout))
+	whys := report.ModulesWhy(goModFile, fs.Args())
+	for _, path := range fs.Args() {
+		fmt.Printf(c.Color("\n[bold]%s[reset]"), path)
+		if version := vlF(path); version != "" {
+			fmt.Printf(" @ %s", version)
+		}
+		fmt.Print("\n - go mod why: ")
+		tw.WriteWhy(whys[path])
+	}
+
+	return exitOK
+}
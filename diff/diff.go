@@ -9,21 +9,134 @@ import (
 	"github.com/radeksimko/go-mod-diff/gomod"
 	"github.com/radeksimko/go-mod-diff/govendor"
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
 )
 
 type Diff struct {
-	Matched   []*DiffEntry
-	NotFound  []*DiffEntry
-	Different []*DiffEntry
-	Errored   []*DiffEntry
+	Matched   []*DiffEntry `json:"matched"`
+	NotFound  []*DiffEntry `json:"not_found"`
+	Different []*DiffEntry `json:"different"`
+	Errored   []*DiffEntry `json:"errored"`
+	// Accepted contains differences accepted via policy
+	Accepted []*DiffEntry `json:"accepted"`
+	// DuplicateMajors are modules required at several major versions
+	DuplicateMajors []*DuplicateMajor `json:"duplicate_majors"`
+}
+
+// Warnings returns entries from all buckets which have any warnings
+func (d *Diff) Warnings() []*DiffEntry {
+	entries := make([]*DiffEntry, 0)
+	for _, bucket := range [][]*DiffEntry{d.Errored, d.NotFound, d.Different, d.Accepted, d.Matched} {
+		for _, entry := range bucket {
+			if len(entry.Warnings) > 0 {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
 }
 
 type DiffEntry struct {
-	ModulePath       string
-	GoModVersion     *Version
+	ModulePath   string
+	GoModVersion *Version
+	// GoModReplacement is the version go.mod replaces the required one with,
+	// nil if the module is not replaced
+	GoModReplacement *Version
 	GithubVersion    *Version
 	GoVendorVersions []*Version
 	Error            error
+
+	// GoModReplacedVersion is the required version the replace directive
+	// applies to, empty if it applies to all versions
+	GoModReplacedVersion string
+
+	// GithubRepository is the canonical repository as reported by GitHub
+	GithubRepository *github.Repository
+	Warnings         []*Warning
+
+	// TagSuggestion is only populated for pseudo-versions by SuggestTags
+	TagSuggestion *TagSuggestion
+	// Reconciliation is only populated for multiple govendor versions by ReconcileVersions
+	Reconciliation *Reconciliation
+	// Justification is only populated for entries in the Accepted bucket
+	Justification string
+}
+
+// ResolvedGoModVersion returns the version go.mod resolves the module to,
+// i.e. the replacement if the module is replaced
+func (de *DiffEntry) ResolvedGoModVersion() *Version {
+	if de.GoModReplacement != nil {
+		return de.GoModReplacement
+	}
+	return de.GoModVersion
+}
+
+// Repository returns the GitHub repository of the module
+// or nil if the module is not hosted on GitHub
+func (de *DiffEntry) Repository() *github.Repository {
+	if de.GithubRepository != nil {
+		return de.GithubRepository
+	}
+	repo, err := github.ParseRepositoryURL(de.ModulePath)
+	if err != nil {
+		return nil
+	}
+	return repo
+}
+
+// VersionRepository returns the GitHub repository the version comes from,
+// which may be a fork of the module repository
+func (de *DiffEntry) VersionRepository(v *Version) *github.Repository {
+	if v.Origin == "" {
+		return de.Repository()
+	}
+	repo, err := github.ParseRepositoryURL(v.Origin)
+	if err != nil {
+		return nil
+	}
+	return repo
+}
+
+// GithubTreeURL returns URL of the go.mod version of the module on GitHub
+// or empty string if the module is not hosted on GitHub
+func (de *DiffEntry) GithubTreeURL() string {
+	return de.VersionTreeURL(de.GoModVersion)
+}
+
+// VersionTreeURL returns URL of the version in its GitHub repository
+// or empty string if it is not hosted on GitHub
+func (de *DiffEntry) VersionTreeURL(v *Version) string {
+	repo := de.VersionRepository(v)
+	if repo == nil {
+		return ""
+	}
+
+	ref, err := gomod.ParseRefFromVersion(v.Version)
+	if err != nil {
+		return ""
+	}
+	return github.TreeURL(repo, ref.String())
+}
+
+type WarningKind string
+
+const (
+	WarningRepositoryMoved        WarningKind = "moved"
+	WarningRepositoryArchived     WarningKind = "archived"
+	WarningRepositoryNotFound     WarningKind = "not_found"
+	WarningRepositoryLookupFailed WarningKind = "lookup_failed"
+	WarningTagLookupFailed        WarningKind = "tag_lookup_failed"
+	WarningReconciliationFailed   WarningKind = "reconciliation_failed"
+	WarningOriginMismatch         WarningKind = "origin_mismatch"
+)
+
+type Warning struct {
+	Kind    WarningKind `json:"kind"`
+	Message string      `json:"message"`
+}
+
+func (w *Warning) String() string {
+	return w.Message
 }
 
 type Version struct {
@@ -31,6 +144,16 @@ type Version struct {
 	Revision   string
 	Time       string
 	isRevision bool
+
+	// Tag is the semver tag govendor fetched the revision by (govendor only)
+	Tag string
+
+	// Origin is the path of the module (fork) the version comes from,
+	// empty if it comes from the module itself
+	Origin string
+
+	// Packages lists vendored packages pinned to this version (govendor only)
+	Packages []string
 }
 
 func (v *Version) String() string {
@@ -38,6 +161,12 @@ func (v *Version) String() string {
 	if v.Revision != "" && v.Version != v.Revision {
 		output += fmt.Sprintf(" / %s", v.Revision)
 	}
+	if v.Tag != "" {
+		output += fmt.Sprintf(" / %s", v.Tag)
+	}
+	if v.Origin != "" {
+		output += fmt.Sprintf(" from %s", v.Origin)
+	}
 	if v.Time != "" {
 		output += fmt.Sprintf(" (%s)", v.Time)
 	}
@@ -68,7 +197,10 @@ func CompareGoModWithGovendor(goModFile *modfile.File, gvFile *vendorfile.File,
 		NotFound:  make([]*DiffEntry, 0),
 		Different: make([]*DiffEntry, 0),
 		Errored:   make([]*DiffEntry, 0),
+		Accepted:  make([]*DiffEntry, 0),
 	}
+	statuses := newRepositoryStatuses(gh)
+	required := gomod.RequiredPaths(goModFile)
 
 	for _, r := range goModFile.Require {
 		mv := r.Mod
@@ -80,27 +212,44 @@ func CompareGoModWithGovendor(goModFile *modfile.File, gvFile *vendorfile.File,
 			},
 		}
 
-		ref, err := gomod.ParseRefFromVersion(mv.Version)
+		// Replacements with local directories have no version to compare
+		if rep := gomod.FindReplace(goModFile, mv); rep != nil && rep.New.Version != "" {
+			diffEntry.GoModReplacement = &Version{
+				Version: rep.New.Version,
+			}
+			diffEntry.GoModReplacedVersion = rep.Old.Version
+			if rep.New.Path != mv.Path {
+				diffEntry.GoModReplacement.Origin = rep.New.Path
+			}
+		}
+		resolved := diffEntry.ResolvedGoModVersion()
+		origin := moduleOrigin(resolved, mv.Path)
+
+		ref, err := parseGoModVersion(diffEntry.GoModVersion)
+		if err == nil && diffEntry.GoModReplacement != nil {
+			ref, err = parseGoModVersion(diffEntry.GoModReplacement)
+		}
 		if err != nil {
 			diffEntry.Error = err
 			d.Errored = append(d.Errored, diffEntry)
 			continue
 		}
-		diffEntry.GoModVersion.isRevision = ref.IsRevision()
-		if ref.IsRevision() {
-			diffEntry.GoModVersion.Revision = ref.String()
-		}
 
-		repo, err := github.ParseRepositoryURL(mv.Path)
+		repo, err := github.ParseRepositoryURL(origin)
 		isGithubURL := (err == nil)
+		if moduleRepo := diffEntry.Repository(); moduleRepo != nil {
+			checkRepository(diffEntry, moduleRepo, statuses)
+		}
 
-		govendorPkgs := govendor.FindPackages(gvFile.Package, mv.Path)
+		gvVersions := GovendorVersions(govendor.PackagesForModule(gvFile.Package, required, mv.Path), mv.Path)
+		checkOrigins(diffEntry, gvVersions)
+		sameOrigin := len(gvVersions) == 1 && moduleOrigin(gvVersions[0], mv.Path) == origin
 
-		if len(govendorPkgs) == 1 && ref.IsRevision() && strings.HasPrefix(govendorPkgs[0].Revision, ref.String()) {
-			diffEntry.GoVendorVersions = govendorVersions(govendorPkgs)
+		if sameOrigin && ref.IsRevision() && strings.HasPrefix(gvVersions[0].Revision, ref.String()) {
+			diffEntry.GoVendorVersions = gvVersions
 			d.Matched = append(d.Matched, diffEntry)
 			continue
-		} else if len(govendorPkgs) > 0 {
+		} else if len(gvVersions) > 0 {
 			if !ref.IsRevision() && isGithubURL {
 				// Try converting reference to a revision via GitHub and compare
 				githubSHA, err := gh.GetCommitSHA(repo, ref.String())
@@ -117,14 +266,14 @@ func CompareGoModWithGovendor(goModFile *modfile.File, gvFile *vendorfile.File,
 					isRevision: true,
 				}
 
-				if len(govendorPkgs) == 1 && govendorPkgs[0].Revision == githubSHA {
-					diffEntry.GoVendorVersions = govendorVersions(govendorPkgs)
+				if sameOrigin && gvVersions[0].Revision == githubSHA {
+					diffEntry.GoVendorVersions = gvVersions
 					d.Matched = append(d.Matched, diffEntry)
 					continue
 				}
 			}
 
-			diffEntry.GoVendorVersions = govendorVersions(govendorPkgs)
+			diffEntry.GoVendorVersions = gvVersions
 			d.Different = append(d.Different, diffEntry)
 			continue
 		}
@@ -132,18 +281,170 @@ func CompareGoModWithGovendor(goModFile *modfile.File, gvFile *vendorfile.File,
 		d.NotFound = append(d.NotFound, diffEntry)
 	}
 
+	d.DuplicateMajors = FindDuplicateMajors(d)
+
 	return d, nil
 }
 
-func govendorVersions(pkgs []*vendorfile.Package) []*Version {
+// parseGoModVersion parses the reference of the go.mod version
+// and records whether it is a revision
+func parseGoModVersion(v *Version) (*gomod.VersionRef, error) {
+	ref, err := gomod.ParseRefFromVersion(v.Version)
+	if err != nil {
+		return nil, err
+	}
+	v.isRevision = ref.IsRevision()
+	if ref.IsRevision() {
+		v.Revision = ref.String()
+	}
+	return ref, nil
+}
+
+// repositoryStatuses caches repository lookups, as several modules
+// (e.g. major versions) may live in the same repository
+type repositoryStatuses struct {
+	gh       *github.GitHub
+	statuses map[github.Repository]*github.RepositoryStatus
+	errors   map[github.Repository]error
+}
+
+func newRepositoryStatuses(gh *github.GitHub) *repositoryStatuses {
+	return &repositoryStatuses{
+		gh:       gh,
+		statuses: make(map[github.Repository]*github.RepositoryStatus, 0),
+		errors:   make(map[github.Repository]error, 0),
+	}
+}
+
+func (rs *repositoryStatuses) Get(repo *github.Repository) (*github.RepositoryStatus, error) {
+	if err, ok := rs.errors[*repo]; ok {
+		return nil, err
+	}
+	if status, ok := rs.statuses[*repo]; ok {
+		return status, nil
+	}
+
+	status, err := rs.gh.GetRepositoryStatus(repo)
+	if err != nil {
+		rs.errors[*repo] = err
+		return nil, err
+	}
+	rs.statuses[*repo] = status
+	return status, nil
+}
+
+// checkRepository records warnings about renamed, transferred, archived
+// or deleted repositories
+func checkRepository(de *DiffEntry, repo *github.Repository, statuses *repositoryStatuses) {
+	status, err := statuses.Get(repo)
+	if err != nil {
+		de.Warnings = append(de.Warnings, &Warning{
+			Kind:    WarningRepositoryLookupFailed,
+			Message: fmt.Sprintf("Failed to look up repository on GitHub: %s", err),
+		})
+		return
+	}
+
+	if status.NotFound {
+		de.Warnings = append(de.Warnings, &Warning{
+			Kind:    WarningRepositoryNotFound,
+			Message: fmt.Sprintf("Repository %s not found on GitHub (deleted or private)", repo),
+		})
+		return
+	}
+
+	de.GithubRepository = status.Canonical
+	if *status.Canonical != *repo {
+		de.Warnings = append(de.Warnings, &Warning{
+			Kind: WarningRepositoryMoved,
+			Message: fmt.Sprintf("Repository %s was renamed or transferred to %s",
+				repo, status.Canonical),
+		})
+	}
+	if status.Archived {
+		de.Warnings = append(de.Warnings, &Warning{
+			Kind:    WarningRepositoryArchived,
+			Message: fmt.Sprintf("Repository %s is archived", status.Canonical),
+		})
+	}
+}
+
+// GovendorVersions returns one version per distinct revision (and origin)
+// along with packages pinned to it
+func GovendorVersions(pkgs []*vendorfile.Package, modulePath string) []*Version {
 	versions := make([]*Version, 0)
+	byRevision := make(map[string]*Version, 0)
 	for _, pkg := range pkgs {
-		versions = append(versions, &Version{
+		origin := packageOrigin(pkg, modulePath)
+		key := pkg.Revision + "@" + origin
+		tag := ""
+		if semver.IsValid(pkg.VersionExact) {
+			tag = pkg.VersionExact
+		}
+		if v, ok := byRevision[key]; ok {
+			v.Packages = append(v.Packages, pkg.Path)
+			if v.Tag == "" {
+				v.Tag = tag
+			}
+			continue
+		}
+
+		v := &Version{
 			Version:    pkg.Revision,
 			Revision:   pkg.Revision,
 			Time:       pkg.RevisionTime,
 			isRevision: true,
-		})
+			Tag:        tag,
+			Origin:     origin,
+			Packages:   []string{pkg.Path},
+		}
+		byRevision[key] = v
+		versions = append(versions, v)
 	}
 	return versions
 }
+
+// packageOrigin returns path of the module the package was vendored from
+// if it differs from modulePath, i.e. if it was vendored from a fork
+func packageOrigin(pkg *vendorfile.Package, modulePath string) string {
+	if pkg.Origin == "" {
+		return ""
+	}
+
+	// Packages copied from vendor directory of another project are not forks
+	origin := pkg.Origin
+	if i := strings.LastIndex(origin, "/vendor/"); i != -1 {
+		origin = origin[i+len("/vendor/"):]
+	}
+
+	rel := strings.TrimPrefix(pkg.Path, modulePath)
+	origin = strings.TrimSuffix(origin, rel)
+	if origin == modulePath {
+		return ""
+	}
+	return origin
+}
+
+func moduleOrigin(v *Version, modulePath string) string {
+	if v.Origin != "" {
+		return v.Origin
+	}
+	return modulePath
+}
+
+// checkOrigins records warnings about govendor versions
+// which come from a different repository (fork) than the go.mod version
+func checkOrigins(de *DiffEntry, gvVersions []*Version) {
+	goModOrigin := moduleOrigin(de.ResolvedGoModVersion(), de.ModulePath)
+	for _, v := range gvVersions {
+		gvOrigin := moduleOrigin(v, de.ModulePath)
+		if gvOrigin == goModOrigin {
+			continue
+		}
+		de.Warnings = append(de.Warnings, &Warning{
+			Kind: WarningOriginMismatch,
+			Message: fmt.Sprintf("govendor pins %s from %s, but go.mod requires it from %s",
+				v.Revision, gvOrigin, goModOrigin),
+		})
+	}
+}
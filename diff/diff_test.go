@@ -1,7 +1,15 @@
 package diff
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
+
+	"github.com/kardianos/govendor/vendorfile"
+	"github.com/radeksimko/go-mod-diff/github"
+	"golang.org/x/mod/modfile"
 )
 
 func TestVersionIsEqual(t *testing.T) {
@@ -26,3 +34,222 @@ func TestVersionIsEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestPackageOrigin(t *testing.T) {
+	testCases := []struct {
+		pkg            *vendorfile.Package
+		expectedOrigin string
+	}{
+		{
+			&vendorfile.Package{Path: "github.com/hashicorp/hcl/hcl/ast"},
+			"",
+		},
+		{
+			&vendorfile.Package{
+				Path:   "github.com/hashicorp/hcl/hcl/ast",
+				Origin: "github.com/hashicorp/terraform/vendor/github.com/hashicorp/hcl/hcl/ast",
+			},
+			"",
+		},
+		{
+			&vendorfile.Package{
+				Path:   "github.com/hashicorp/hcl/hcl/ast",
+				Origin: "github.com/radeksimko/hcl/hcl/ast",
+			},
+			"github.com/radeksimko/hcl",
+		},
+		{
+			&vendorfile.Package{
+				Path:   "github.com/hashicorp/hcl",
+				Origin: "github.com/hashicorp/terraform/vendor/github.com/radeksimko/hcl",
+			},
+			"github.com/radeksimko/hcl",
+		},
+	}
+
+	for i, tc := range testCases {
+		origin := packageOrigin(tc.pkg, "github.com/hashicorp/hcl")
+		if origin != tc.expectedOrigin {
+			t.Fatalf("%d: expected origin %q, given: %q", i, tc.expectedOrigin, origin)
+		}
+	}
+}
+
+func TestCompareGoModWithGovendor_fork(t *testing.T) {
+	goModFile, err := modfile.Parse("go.mod", []byte(`module example.com/foo
+
+require (
+	golang.org/x/net v0.0.0-20180413091243-1c05540f6879
+	golang.org/x/text v0.0.0-20180413091243-3b0461eec859
+)
+
+replace golang.org/x/text => example.com/fork/text v0.0.0-20180416091243-f21a4dfb5e38
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gvFile := &vendorfile.File{
+		Package: []*vendorfile.Package{
+			{
+				Path:         "golang.org/x/net/context",
+				Origin:       "example.com/fork/net/context",
+				Revision:     "1c05540f6879653db88113bc4a2b70aec4bd491f",
+				RevisionTime: "2018-04-13T09:12:43Z",
+			},
+			{
+				Path:         "golang.org/x/text/unicode",
+				Origin:       "example.com/fork/text/unicode",
+				Revision:     "f21a4dfb5e38f5895301dc265a8def02365cc3d0",
+				RevisionTime: "2018-04-16T09:12:43Z",
+			},
+		},
+	}
+
+	d, err := CompareGoModWithGovendor(goModFile, gvFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.Different) != 1 || d.Different[0].ModulePath != "golang.org/x/net" {
+		t.Fatalf("Expected golang.org/x/net to be different, given: %#v", d.Different)
+	}
+	net := d.Different[0]
+	if net.GoVendorVersions[0].Origin != "example.com/fork/net" {
+		t.Fatalf("Expected fork origin, given: %q", net.GoVendorVersions[0].Origin)
+	}
+	if len(net.Warnings) != 1 || net.Warnings[0].Kind != WarningOriginMismatch {
+		t.Fatalf("Expected origin mismatch warning, given: %#v", net.Warnings)
+	}
+
+	if len(d.Matched) != 1 || d.Matched[0].ModulePath != "golang.org/x/text" {
+		t.Fatalf("Expected replaced golang.org/x/text to match, given: %#v", d.Matched)
+	}
+	text := d.Matched[0]
+	if v := text.GoModVersion.Version; v != "v0.0.0-20180413091243-3b0461eec859" {
+		t.Fatalf("Expected required version to be kept, given: %q", v)
+	}
+	if text.GoModReplacement == nil || text.GoModReplacement.Origin != "example.com/fork/text" {
+		t.Fatalf("Expected go.mod replacement from fork, given: %#v", text.GoModReplacement)
+	}
+	if v := text.GoModReplacement.Version; v != "v0.0.0-20180416091243-f21a4dfb5e38" {
+		t.Fatalf("Expected version of replacement, given: %q", v)
+	}
+}
+
+func TestCompareGoModWithGovendor_github(t *testing.T) {
+	requests := make([]string, 0)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.RequestURI)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		switch r.RequestURI {
+		case "/repos/hashicorp/hcl/commits/v1.0.0":
+			fmt.Fprintln(w, `{"sha": "8cb6e5b959231cc1119e43259c4a608f9c51a241"}`)
+		case "/repos/hashicorp/go-version":
+			fmt.Fprintln(w, `{"name": "go-version", "owner": {"login": "hashicorp"}, "archived": true}`)
+		case "/repos/hashicorp/hcl":
+			fmt.Fprintln(w, `{"name": "hcl", "owner": {"login": "hashicorp"}}`)
+		case "/repos/Sirupsen/logrus":
+			fmt.Fprintln(w, `{"name": "logrus", "owner": {"login": "sirupsen"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, `{"message": "Not Found"}`)
+		}
+	}))
+	defer ts.Close()
+
+	goModFile, err := modfile.Parse("go.mod", []byte(`module example.com/foo
+
+require (
+	github.com/Sirupsen/logrus v0.0.0-20180523074243-ea8897e79973
+	github.com/hashicorp/go-version v0.0.0-20180716215031-270f2f71b1ee
+	github.com/hashicorp/hcl v1.0.0
+	github.com/hashicorp/hcl/v2 v2.0.0
+	github.com/hashicorp/terraform v0.0.0-20190227065421-fc531f54a878
+)
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gvFile := &vendorfile.File{
+		Package: []*vendorfile.Package{
+			{Path: "github.com/Sirupsen/logrus", Revision: "ea8897e79973357ba785ac2533559a6297e83c44"},
+			{Path: "github.com/hashicorp/go-version", Revision: "23480c0665776210b5fbbac6eaaee40e3e6a96b7"},
+			{Path: "github.com/hashicorp/hcl", Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241"},
+			{Path: "github.com/hashicorp/terraform", Revision: "fc531f54a878a9ed6b1fea3fb8e9fd2b3cbbbb3a"},
+		},
+	}
+
+	d, err := CompareGoModWithGovendor(goModFile, gvFile, github.NewGitHubWithURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.Matched) != 3 || len(d.Different) != 1 || len(d.NotFound) != 1 {
+		t.Fatalf("Expected 3 matched, 1 different and 1 not found entries, given: %#v", d)
+	}
+	// Repository shared by both hcl majors is looked up once
+	expectedRequests := []string{
+		"/repos/Sirupsen/logrus",
+		"/repos/hashicorp/go-version",
+		"/repos/hashicorp/hcl",
+		"/repos/hashicorp/hcl/commits/v1.0.0",
+		"/repos/hashicorp/terraform",
+	}
+	if !reflect.DeepEqual(expectedRequests, requests) {
+		t.Fatalf("Expected requests: %q\ngiven: %q", expectedRequests, requests)
+	}
+
+	warnings := d.Different[0].Warnings
+	if len(warnings) != 1 || warnings[0].Kind != WarningRepositoryArchived {
+		t.Fatalf("Expected archived repository warning, given: %#v", warnings)
+	}
+	expectedWarnings := map[string]WarningKind{
+		"github.com/Sirupsen/logrus":     WarningRepositoryMoved,
+		"github.com/hashicorp/terraform": WarningRepositoryNotFound,
+	}
+	for _, entry := range d.Matched {
+		kind, ok := expectedWarnings[entry.ModulePath]
+		if !ok {
+			if len(entry.Warnings) > 0 {
+				t.Fatalf("Expected no warnings for %s, given: %#v", entry.ModulePath, entry.Warnings)
+			}
+			continue
+		}
+		if len(entry.Warnings) != 1 || entry.Warnings[0].Kind != kind {
+			t.Fatalf("Expected %s warning for %s, given: %#v", kind, entry.ModulePath, entry.Warnings)
+		}
+	}
+	expectedRepo := &github.Repository{Owner: "sirupsen", Name: "logrus"}
+	if repo := d.Matched[0].Repository(); !reflect.DeepEqual(expectedRepo, repo) {
+		t.Fatalf("Expected canonical repository %s, given: %s", expectedRepo, repo)
+	}
+}
+
+func TestDiffEntryVersionRepository(t *testing.T) {
+	de := &DiffEntry{
+		ModulePath:   "github.com/hashicorp/hcl",
+		GoModVersion: &Version{Version: "v1.0.0"},
+		GoModReplacement: &Version{
+			Version: "v0.0.0-20180416091243-f21a4dfb5e38",
+			Origin:  "github.com/fork/hcl",
+		},
+	}
+
+	expectedRepo := &github.Repository{Owner: "hashicorp", Name: "hcl"}
+	if repo := de.Repository(); !reflect.DeepEqual(expectedRepo, repo) {
+		t.Fatalf("Expected module repository %s, given: %s", expectedRepo, repo)
+	}
+	expectedFork := &github.Repository{Owner: "fork", Name: "hcl"}
+	if repo := de.VersionRepository(de.GoModReplacement); !reflect.DeepEqual(expectedFork, repo) {
+		t.Fatalf("Expected fork repository %s, given: %s", expectedFork, repo)
+	}
+
+	expectedURL := "https://github.com/hashicorp/hcl/tree/v1.0.0"
+	if url := de.GithubTreeURL(); url != expectedURL {
+		t.Fatalf("Expected %q, given: %q", expectedURL, url)
+	}
+	expectedURL = "https://github.com/fork/hcl/tree/f21a4dfb5e38"
+	if url := de.VersionTreeURL(de.GoModReplacement); url != expectedURL {
+		t.Fatalf("Expected %q, given: %q", expectedURL, url)
+	}
+}
@@ -0,0 +1,154 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// ImpactKind classifies the change from govendor version to go.mod version
+type ImpactKind string
+
+const (
+	ImpactMajor      ImpactKind = "major"
+	ImpactMinor      ImpactKind = "minor"
+	ImpactPatch      ImpactKind = "patch"
+	ImpactPrerelease ImpactKind = "prerelease"
+	// ImpactPseudo is a change from or to an untagged revision (pseudo-version)
+	// where the size of the change is unknown
+	ImpactPseudo ImpactKind = "pseudo"
+)
+
+// Severity returns how serious the change is, higher is more serious
+func (k ImpactKind) Severity() int {
+	switch k {
+	case ImpactMajor:
+		return 5
+	case ImpactMinor:
+		return 4
+	case ImpactPatch:
+		return 3
+	case ImpactPrerelease:
+		return 2
+	case ImpactPseudo:
+		return 1
+	}
+	return 0
+}
+
+// Impact describes the semver change from govendor version to go.mod version
+type Impact struct {
+	Kind ImpactKind `json:"kind"`
+	// From is the govendor tag, or revision if govendor didn't pin a tag
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Downgrade is true if go.mod version is lower than govendor version
+	Downgrade bool `json:"downgrade,omitempty"`
+	// Incompatible is true if either version is +incompatible,
+	// i.e. v2+ version of a module without go.mod
+	Incompatible bool `json:"incompatible,omitempty"`
+}
+
+func (i *Impact) String() string {
+	output := fmt.Sprintf("%s (%s → %s)", i.Kind, i.From, i.To)
+	if i.Downgrade {
+		output += ", downgrade"
+	}
+	if i.Incompatible {
+		output += ", +incompatible"
+	}
+	return output
+}
+
+// pseudoVersionRe matches pseudo-versions, as defined by cmd/go
+var pseudoVersionRe = regexp.MustCompile(`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+func isPseudoVersion(v string) bool {
+	return strings.Count(v, "-") >= 2 && semver.IsValid(v) && pseudoVersionRe.MatchString(v)
+}
+
+// ClassifyImpact classifies the change from the govendor version to the go.mod version.
+// It returns nil if both are the same semver version or neither is tagged.
+func ClassifyImpact(from *Version, to string) *Impact {
+	if !semver.IsValid(to) || (from.Tag == "" && isPseudoVersion(to)) {
+		return nil
+	}
+	i := &Impact{
+		From:         from.Tag,
+		To:           to,
+		Incompatible: isIncompatible(from.Tag) || isIncompatible(to),
+	}
+	if from.Tag == "" {
+		i.From = from.Revision
+		if len(i.From) > 12 {
+			i.From = i.From[0:12]
+		}
+		i.Kind = ImpactPseudo
+		return i
+	}
+
+	if semver.Compare(from.Tag, to) == 0 {
+		return nil
+	}
+	i.Downgrade = semver.Compare(to, from.Tag) < 0
+
+	// Pseudo-versions without any tag in history don't tell the major version
+	hasMajor := func(v string) bool {
+		return !strings.HasPrefix(v, "v0.0.0-")
+	}
+
+	switch {
+	case semver.Major(from.Tag) != semver.Major(to) && hasMajor(from.Tag) && hasMajor(to):
+		i.Kind = ImpactMajor
+	case isPseudoVersion(from.Tag) || isPseudoVersion(to):
+		i.Kind = ImpactPseudo
+	case semver.MajorMinor(from.Tag) != semver.MajorMinor(to):
+		i.Kind = ImpactMinor
+	case withoutPrerelease(from.Tag) != withoutPrerelease(to):
+		i.Kind = ImpactPatch
+	default:
+		i.Kind = ImpactPrerelease
+	}
+	return i
+}
+
+// Impact returns the most serious change from any govendor version
+// to the go.mod version, or nil if there is no change
+func (de *DiffEntry) Impact() *Impact {
+	var impact *Impact
+	for _, v := range de.GoVendorVersions {
+		if v.IsEqual(de.ResolvedGoModVersion()) || v.IsEqual(de.GithubVersion) {
+			continue
+		}
+		i := ClassifyImpact(v, de.GoModVersion.Version)
+		if i != nil && (impact == nil || i.Kind.Severity() > impact.Kind.Severity()) {
+			impact = i
+		}
+	}
+	return impact
+}
+
+// SortByImpact sorts entries by severity of their impact, most serious first
+func SortByImpact(entries []*DiffEntry) {
+	severity := func(de *DiffEntry) int {
+		if i := de.Impact(); i != nil {
+			return i.Kind.Severity()
+		}
+		return 0
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return severity(entries[i]) > severity(entries[j])
+	})
+}
+
+func isIncompatible(v string) bool {
+	return semver.Build(v) == "+incompatible"
+}
+
+func withoutPrerelease(v string) string {
+	v = semver.Canonical(v)
+	return strings.TrimSuffix(v, semver.Prerelease(v))
+}
@@ -0,0 +1,105 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClassifyImpact(t *testing.T) {
+	testCases := []struct {
+		from     *Version
+		to       string
+		expected *Impact
+	}{
+		{
+			&Version{Revision: "a4b7f9ee5bcba0c5b9a9a3fc1a4ac4a4a7c5ab7d", Tag: "v1.2.0"},
+			"v1.2.0",
+			nil,
+		},
+		{
+			&Version{Tag: "v1.2.0"},
+			"v2.0.0+incompatible",
+			&Impact{Kind: ImpactMajor, From: "v1.2.0", To: "v2.0.0+incompatible", Incompatible: true},
+		},
+		{
+			&Version{Tag: "v2.1.0+incompatible"},
+			"v2.0.0+incompatible",
+			&Impact{Kind: ImpactMinor, From: "v2.1.0+incompatible", To: "v2.0.0+incompatible",
+				Downgrade: true, Incompatible: true},
+		},
+		{
+			&Version{Tag: "v1.2.0"},
+			"v1.2.3",
+			&Impact{Kind: ImpactPatch, From: "v1.2.0", To: "v1.2.3"},
+		},
+		{
+			&Version{Tag: "v1.2.0-rc.1"},
+			"v1.2.0",
+			&Impact{Kind: ImpactPrerelease, From: "v1.2.0-rc.1", To: "v1.2.0"},
+		},
+		{
+			&Version{Tag: "v1.2.0"},
+			"v1.2.1-0.20190227065421-fc531f54a878",
+			&Impact{Kind: ImpactPseudo, From: "v1.2.0", To: "v1.2.1-0.20190227065421-fc531f54a878"},
+		},
+		// Pseudo-version based on a tag of different major version
+		{
+			&Version{Tag: "v1.2.0"},
+			"v2.0.1-0.20190227065421-fc531f54a878+incompatible",
+			&Impact{Kind: ImpactMajor, From: "v1.2.0", To: "v2.0.1-0.20190227065421-fc531f54a878+incompatible",
+				Incompatible: true},
+		},
+		// Pseudo-version without any tag in history
+		{
+			&Version{Tag: "v1.2.0"},
+			"v0.0.0-20190227065421-fc531f54a878",
+			&Impact{Kind: ImpactPseudo, From: "v1.2.0", To: "v0.0.0-20190227065421-fc531f54a878", Downgrade: true},
+		},
+		// Untagged govendor revision
+		{
+			&Version{Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f"},
+			"v1.2.0",
+			&Impact{Kind: ImpactPseudo, From: "1c05540f6879", To: "v1.2.0"},
+		},
+		// Neither version is tagged
+		{
+			&Version{Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f"},
+			"v0.0.0-20190227065421-fc531f54a878",
+			nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		impact := ClassifyImpact(tc.from, tc.to)
+		if !reflect.DeepEqual(tc.expected, impact) {
+			t.Fatalf("%d: Expected: %#v\ngiven: %#v", i, tc.expected, impact)
+		}
+	}
+}
+
+func TestSortByImpact(t *testing.T) {
+	entry := func(path, goModVersion, tag string) *DiffEntry {
+		return &DiffEntry{
+			ModulePath:       path,
+			GoModVersion:     &Version{Version: goModVersion},
+			GoVendorVersions: []*Version{{Version: "1c05540f6879", Revision: "1c05540f6879", Tag: tag}},
+		}
+	}
+	entries := []*DiffEntry{
+		entry("example.com/pseudo", "v1.0.0", ""),
+		entry("example.com/patch", "v1.0.1", "v1.0.0"),
+		entry("example.com/major", "v2.0.0+incompatible", "v1.0.0"),
+		entry("example.com/minor", "v1.1.0", "v1.0.0"),
+	}
+
+	SortByImpact(entries)
+
+	expected := []string{"example.com/major", "example.com/minor", "example.com/patch", "example.com/pseudo"}
+	given := make([]string, 0)
+	for _, e := range entries {
+		given = append(given, e.ModulePath)
+	}
+	if !reflect.DeepEqual(expected, given) {
+		t.Fatalf("Expected: %q\ngiven: %q", expected, given)
+	}
+}
@@ -0,0 +1,151 @@
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/radeksimko/go-mod-diff/github"
+)
+
+type jsonVersion struct {
+	Version    string   `json:"version"`
+	Revision   string   `json:"revision,omitempty"`
+	Time       string   `json:"time,omitempty"`
+	IsRevision bool     `json:"is_revision"`
+	Tag        string   `json:"tag,omitempty"`
+	Origin     string   `json:"origin,omitempty"`
+	Packages   []string `json:"packages,omitempty"`
+}
+
+func (v *Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonVersion{
+		Version:    v.Version,
+		Revision:   v.Revision,
+		Time:       v.Time,
+		IsRevision: v.isRevision,
+		Tag:        v.Tag,
+		Origin:     v.Origin,
+		Packages:   v.Packages,
+	})
+}
+
+func (v *Version) UnmarshalJSON(b []byte) error {
+	var jv jsonVersion
+	err := json.Unmarshal(b, &jv)
+	if err != nil {
+		return err
+	}
+
+	*v = Version{
+		Version:    jv.Version,
+		Revision:   jv.Revision,
+		Time:       jv.Time,
+		isRevision: jv.IsRevision,
+		Tag:        jv.Tag,
+		Origin:     jv.Origin,
+		Packages:   jv.Packages,
+	}
+	return nil
+}
+
+type jsonDiffEntry struct {
+	ModulePath       string             `json:"module_path"`
+	GoModVersion     *Version           `json:"go_mod_version"`
+	GoModReplacement *Version           `json:"go_mod_replacement,omitempty"`
+	GithubVersion    *Version           `json:"github_version,omitempty"`
+	GoVendorVersions []*Version         `json:"govendor_versions"`
+	Error            string             `json:"error,omitempty"`
+	GithubRepository *github.Repository `json:"github_repository,omitempty"`
+	GithubURL        string             `json:"github_url,omitempty"`
+	Warnings         []*Warning         `json:"warnings"`
+	TagSuggestion    *TagSuggestion     `json:"tag_suggestion,omitempty"`
+	Reconciliation   *Reconciliation    `json:"reconciliation,omitempty"`
+	Impact           *Impact            `json:"impact,omitempty"`
+	Justification    string             `json:"justification,omitempty"`
+}
+
+func (de *DiffEntry) MarshalJSON() ([]byte, error) {
+	jde := &jsonDiffEntry{
+		ModulePath:       de.ModulePath,
+		GoModVersion:     de.GoModVersion,
+		GoModReplacement: de.GoModReplacement,
+		GithubVersion:    de.GithubVersion,
+		GoVendorVersions: de.GoVendorVersions,
+		GithubRepository: de.GithubRepository,
+		GithubURL:        de.GithubTreeURL(),
+		Warnings:         de.Warnings,
+		TagSuggestion:    de.TagSuggestion,
+		Reconciliation:   de.Reconciliation,
+		Justification:    de.Justification,
+		Impact:           de.Impact(),
+	}
+	if jde.GoVendorVersions == nil {
+		jde.GoVendorVersions = make([]*Version, 0)
+	}
+	if jde.Warnings == nil {
+		jde.Warnings = make([]*Warning, 0)
+	}
+	if de.Error != nil {
+		jde.Error = de.Error.Error()
+	}
+
+	return json.Marshal(jde)
+}
+
+func (de *DiffEntry) UnmarshalJSON(b []byte) error {
+	var jde jsonDiffEntry
+	err := json.Unmarshal(b, &jde)
+	if err != nil {
+		return err
+	}
+
+	*de = DiffEntry{
+		ModulePath:       jde.ModulePath,
+		GoModVersion:     jde.GoModVersion,
+		GoModReplacement: jde.GoModReplacement,
+		GithubVersion:    jde.GithubVersion,
+		GoVendorVersions: jde.GoVendorVersions,
+		GithubRepository: jde.GithubRepository,
+		Warnings:         jde.Warnings,
+		TagSuggestion:    jde.TagSuggestion,
+		Reconciliation:   jde.Reconciliation,
+		Justification:    jde.Justification,
+	}
+	if jde.Error != "" {
+		de.Error = errors.New(jde.Error)
+	}
+	return nil
+}
+
+type jsonPackageMove struct {
+	Package       string             `json:"package"`
+	From          *Version           `json:"from"`
+	BehindSeconds int64              `json:"behind_seconds,omitempty"`
+	Comparison    *github.Comparison `json:"comparison,omitempty"`
+}
+
+func (pm *PackageMove) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonPackageMove{
+		Package:       pm.Package,
+		From:          pm.From,
+		BehindSeconds: int64(pm.Behind.Seconds()),
+		Comparison:    pm.Comparison,
+	})
+}
+
+func (pm *PackageMove) UnmarshalJSON(b []byte) error {
+	var jpm jsonPackageMove
+	err := json.Unmarshal(b, &jpm)
+	if err != nil {
+		return err
+	}
+
+	*pm = PackageMove{
+		Package:    jpm.Package,
+		From:       jpm.From,
+		Behind:     time.Duration(jpm.BehindSeconds) * time.Second,
+		Comparison: jpm.Comparison,
+	}
+	return nil
+}
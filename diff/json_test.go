@@ -0,0 +1,62 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDiffEntryJSON(t *testing.T) {
+	entry := &DiffEntry{
+		ModulePath: "github.com/hashicorp/hcl",
+		GoModVersion: &Version{
+			Version:    "v0.0.0-20170808112155-b176d7def5d7",
+			Revision:   "b176d7def5d7",
+			isRevision: true,
+		},
+		GoVendorVersions: []*Version{
+			{
+				Version:    "b176d7def5d71bdd214203491f89843ed217f420",
+				Revision:   "b176d7def5d71bdd214203491f89843ed217f420",
+				Time:       "2017-07-23T04:49:35Z",
+				isRevision: true,
+				Packages:   []string{"github.com/hashicorp/hcl"},
+			},
+		},
+		Error:    fmt.Errorf("Failed to get ref SHA from GitHub: boom"),
+		Warnings: []*Warning{},
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedJSON := `{"module_path":"github.com/hashicorp/hcl",` +
+		`"go_mod_version":{"version":"v0.0.0-20170808112155-b176d7def5d7","revision":"b176d7def5d7","is_revision":true},` +
+		`"govendor_versions":[{"version":"b176d7def5d71bdd214203491f89843ed217f420",` +
+		`"revision":"b176d7def5d71bdd214203491f89843ed217f420","time":"2017-07-23T04:49:35Z",` +
+		`"is_revision":true,"packages":["github.com/hashicorp/hcl"]}],` +
+		`"error":"Failed to get ref SHA from GitHub: boom",` +
+		`"github_url":"https://github.com/hashicorp/hcl/tree/b176d7def5d7","warnings":[]}`
+	if string(b) != expectedJSON {
+		t.Fatalf("Expected: %s\ngiven: %s", expectedJSON, string(b))
+	}
+
+	var decoded DiffEntry
+	err = json.Unmarshal(b, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.GoModVersion.IsRevision() {
+		t.Fatal("Expected decoded go.mod version to be a revision")
+	}
+	if decoded.Error == nil || decoded.Error.Error() != entry.Error.Error() {
+		t.Fatalf("Expected error %q, given: %v", entry.Error, decoded.Error)
+	}
+	decoded.Error = entry.Error
+	if !reflect.DeepEqual(entry, &decoded) {
+		t.Fatalf("Expected %#v, given: %#v", entry, &decoded)
+	}
+}
@@ -0,0 +1,68 @@
+package diff
+
+import (
+	"sort"
+
+	"golang.org/x/mod/module"
+)
+
+// DuplicateMajor is a module required at several major versions,
+// e.g. as github.com/foo/bar (v2.0.0+incompatible) and github.com/foo/bar/v2
+type DuplicateMajor struct {
+	// Path is the module path without major version suffix
+	Path        string   `json:"path"`
+	ModulePaths []string `json:"module_paths"`
+	// SingleRevision is true if govendor pins all of them to a single revision
+	SingleRevision bool         `json:"single_revision"`
+	Entries        []*DiffEntry `json:"-"`
+}
+
+// FindDuplicateMajors groups entries of module paths which differ
+// only by major version suffix
+func FindDuplicateMajors(d *Diff) []*DuplicateMajor {
+	byPrefix := make(map[string]*DuplicateMajor, 0)
+	prefixes := make([]string, 0)
+	for _, bucket := range [][]*DiffEntry{d.Errored, d.NotFound, d.Different, d.Accepted, d.Matched} {
+		for _, entry := range bucket {
+			prefix, _, ok := module.SplitPathVersion(entry.ModulePath)
+			if !ok {
+				continue
+			}
+			dm, ok := byPrefix[prefix]
+			if !ok {
+				dm = &DuplicateMajor{Path: prefix}
+				byPrefix[prefix] = dm
+				prefixes = append(prefixes, prefix)
+			}
+			dm.Entries = append(dm.Entries, entry)
+		}
+	}
+	sort.Strings(prefixes)
+
+	duplicates := make([]*DuplicateMajor, 0)
+	for _, prefix := range prefixes {
+		dm := byPrefix[prefix]
+		if len(dm.Entries) < 2 {
+			continue
+		}
+		sort.Slice(dm.Entries, func(i, j int) bool {
+			return dm.Entries[i].ModulePath < dm.Entries[j].ModulePath
+		})
+		for _, entry := range dm.Entries {
+			dm.ModulePaths = append(dm.ModulePaths, entry.ModulePath)
+		}
+		dm.SingleRevision = singleRevision(dm.Entries)
+		duplicates = append(duplicates, dm)
+	}
+	return duplicates
+}
+
+func singleRevision(entries []*DiffEntry) bool {
+	revisions := make(map[string]bool, 0)
+	for _, entry := range entries {
+		for _, v := range entry.GoVendorVersions {
+			revisions[v.Revision] = true
+		}
+	}
+	return len(revisions) == 1
+}
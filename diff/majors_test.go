@@ -0,0 +1,65 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kardianos/govendor/vendorfile"
+	"golang.org/x/mod/modfile"
+)
+
+func TestFindDuplicateMajors(t *testing.T) {
+	goModFile, err := modfile.Parse("go.mod", []byte(`module example.com/foo
+
+require (
+	example.com/bar v2.0.0+incompatible
+	example.com/bar/v2 v2.1.0
+	example.com/baz v0.0.0-20180413091243-1c05540f6879
+	gopkg.in/yaml.v2 v2.2.2
+	gopkg.in/yaml.v3 v3.0.0
+)
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gvFile := &vendorfile.File{
+		Package: []*vendorfile.Package{
+			{
+				Path:     "example.com/bar",
+				Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+			},
+			{
+				Path:     "example.com/baz",
+				Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f",
+			},
+		},
+	}
+
+	d, err := CompareGoModWithGovendor(goModFile, gvFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paths := make(map[string][]string, 0)
+	for _, dm := range d.DuplicateMajors {
+		paths[dm.Path] = dm.ModulePaths
+	}
+	expected := map[string][]string{
+		"example.com/bar": {"example.com/bar", "example.com/bar/v2"},
+		"gopkg.in/yaml":   {"gopkg.in/yaml.v2", "gopkg.in/yaml.v3"},
+	}
+	if !reflect.DeepEqual(expected, paths) {
+		t.Fatalf("Expected: %q\ngiven: %q", expected, paths)
+	}
+
+	if !d.DuplicateMajors[0].SingleRevision {
+		t.Fatalf("Expected %s to be pinned to a single revision", d.DuplicateMajors[0].Path)
+	}
+	if d.DuplicateMajors[1].SingleRevision {
+		t.Fatalf("Expected %s not to be pinned to a single revision", d.DuplicateMajors[1].Path)
+	}
+	// Duplicates are reported on their own, not as repository warnings
+	if warned := d.Warnings(); len(warned) > 0 {
+		t.Fatalf("Expected no warnings, given: %#v", warned)
+	}
+}
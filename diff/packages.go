@@ -0,0 +1,41 @@
+package diff
+
+import (
+	"sort"
+)
+
+// PackageEntry is a single vendored package of a module
+// along with its own govendor revision
+type PackageEntry struct {
+	Package  string `json:"package"`
+	Revision string `json:"revision"`
+	Time     string `json:"time,omitempty"`
+	Origin   string `json:"origin,omitempty"`
+	// Matched is true if the package revision matches the go.mod version
+	Matched bool `json:"matched"`
+}
+
+// PackageEntries lists all vendored packages of the module sorted by path,
+// e.g. to tell which packages of x/net were vendored at an older revision
+func (de *DiffEntry) PackageEntries() []*PackageEntry {
+	entries := make([]*PackageEntry, 0)
+	resolved := de.ResolvedGoModVersion()
+	goModOrigin := moduleOrigin(resolved, de.ModulePath)
+	for _, v := range de.GoVendorVersions {
+		matched := moduleOrigin(v, de.ModulePath) == goModOrigin &&
+			(v.IsEqual(resolved) || v.IsEqual(de.GithubVersion))
+		for _, pkg := range v.Packages {
+			entries = append(entries, &PackageEntry{
+				Package:  pkg,
+				Revision: v.Revision,
+				Time:     v.Time,
+				Origin:   v.Origin,
+				Matched:  matched,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Package < entries[j].Package
+	})
+	return entries
+}
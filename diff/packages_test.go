@@ -0,0 +1,62 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffEntryPackageEntries(t *testing.T) {
+	de := &DiffEntry{
+		ModulePath: "golang.org/x/net",
+		GoModVersion: &Version{
+			Version:  "v0.0.0-20180416091243-3b0461eec859",
+			Revision: "3b0461eec859",
+		},
+		GoVendorVersions: []*Version{
+			{
+				Version:  "1c05540f6879653db88113bc4a2b70aec4bd491f",
+				Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f",
+				Time:     "2018-04-13T09:12:43Z",
+				Packages: []string{"golang.org/x/net/http2", "golang.org/x/net/http2/hpack"},
+			},
+			{
+				Version:  "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+				Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+				Packages: []string{"golang.org/x/net/context"},
+			},
+			{
+				Version:  "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+				Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+				Origin:   "example.com/fork/net",
+				Packages: []string{"golang.org/x/net/idna"},
+			},
+		},
+	}
+
+	expected := []*PackageEntry{
+		{
+			Package:  "golang.org/x/net/context",
+			Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+			Matched:  true,
+		},
+		{
+			Package:  "golang.org/x/net/http2",
+			Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f",
+			Time:     "2018-04-13T09:12:43Z",
+		},
+		{
+			Package:  "golang.org/x/net/http2/hpack",
+			Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f",
+			Time:     "2018-04-13T09:12:43Z",
+		},
+		// Same revision of a fork doesn't match
+		{
+			Package:  "golang.org/x/net/idna",
+			Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+			Origin:   "example.com/fork/net",
+		},
+	}
+	if entries := de.PackageEntries(); !reflect.DeepEqual(expected, entries) {
+		t.Fatalf("Expected: %#v\ngiven: %#v", expected, entries)
+	}
+}
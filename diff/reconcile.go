@@ -0,0 +1,183 @@
+package diff
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/radeksimko/go-mod-diff/github"
+)
+
+// Reconciliation proposes a single version for a module
+// which govendor pinned to several revisions
+type Reconciliation struct {
+	Target *Version       `json:"target"`
+	Moves  []*PackageMove `json:"moves"`
+}
+
+// PackageMove describes a vendored package which would move
+// from its govendor revision to the reconciliation target
+type PackageMove struct {
+	Package string
+	From    *Version
+	// Behind is how much older From is compared to the target (0 if unknown)
+	Behind time.Duration
+	// Comparison of From (base) with the target (head), nil if unknown
+	Comparison *github.Comparison
+}
+
+func (pm *PackageMove) String() string {
+	output := pm.From.Revision
+	if pm.Comparison != nil {
+		if pm.Comparison.Status == "diverged" {
+			output += fmt.Sprintf(", diverged (%d commits behind, %d commits not in target)",
+				pm.Comparison.AheadBy, pm.Comparison.BehindBy)
+		} else {
+			output += fmt.Sprintf(", %d commits behind", pm.Comparison.AheadBy)
+		}
+	}
+	if pm.Behind > 0 {
+		output += fmt.Sprintf(", %s older", humanDuration(pm.Behind))
+	}
+	return output
+}
+
+// ReconcileVersions proposes a target version for every entry
+// with multiple govendor revisions. Commits are only compared via GitHub
+// if gh is not nil.
+func ReconcileVersions(d *Diff, gh *github.GitHub) {
+	for _, entry := range d.Different {
+		if len(entry.GoVendorVersions) < 2 {
+			continue
+		}
+
+		r, err := Reconcile(entry, gh)
+		if err != nil {
+			entry.Warnings = append(entry.Warnings, &Warning{
+				Kind:    WarningReconciliationFailed,
+				Message: fmt.Sprintf("Failed to pick newest govendor revision: %s", err),
+			})
+			continue
+		}
+		entry.Reconciliation = r
+	}
+}
+
+// Reconcile picks the newest of govendor revisions, using revision times
+// where available and commit ancestry from GitHub otherwise.
+// Commit counts of package moves are looked up on a best-effort basis.
+func Reconcile(de *DiffEntry, gh *github.GitHub) (*Reconciliation, error) {
+	versions := de.GoVendorVersions
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("No govendor versions to reconcile")
+	}
+
+	// Revisions can only be compared within a single repository
+	repo := de.VersionRepository(versions[0])
+	for _, v := range versions[1:] {
+		if v.Origin != versions[0].Origin {
+			repo = nil
+			break
+		}
+	}
+
+	times, err := revisionTimes(versions)
+	var target *Version
+	if err == nil {
+		target = newestByTime(versions, times)
+	} else {
+		if gh == nil {
+			return nil, fmt.Errorf("%s and commits are not compared via GitHub", err)
+		}
+		if repo == nil {
+			return nil, fmt.Errorf("%s and revisions cannot be compared on GitHub", err)
+		}
+		target, err = newestByAncestry(versions, repo, gh)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r := &Reconciliation{
+		Target: target,
+		Moves:  make([]*PackageMove, 0),
+	}
+	for _, v := range versions {
+		if v == target {
+			continue
+		}
+
+		var comparison *github.Comparison
+		if repo != nil && gh != nil {
+			// Commit count is only informative, the target is known already
+			if c, err := gh.CompareCommits(repo, v.Revision, target.Revision); err == nil {
+				comparison = c
+			}
+		}
+
+		var behind time.Duration
+		if times != nil {
+			behind = times[target].Sub(times[v])
+		}
+
+		for _, pkg := range v.Packages {
+			r.Moves = append(r.Moves, &PackageMove{
+				Package:    pkg,
+				From:       v,
+				Behind:     behind,
+				Comparison: comparison,
+			})
+		}
+	}
+
+	return r, nil
+}
+
+func revisionTimes(versions []*Version) (map[*Version]time.Time, error) {
+	times := make(map[*Version]time.Time, len(versions))
+	for _, v := range versions {
+		if v.Time == "" {
+			return nil, fmt.Errorf("Revision time of %s unknown", v.Revision)
+		}
+		t, err := time.Parse(time.RFC3339, v.Time)
+		if err != nil {
+			return nil, err
+		}
+		times[v] = t
+	}
+	return times, nil
+}
+
+func newestByTime(versions []*Version, times map[*Version]time.Time) *Version {
+	newest := versions[0]
+	for _, v := range versions[1:] {
+		if times[v].After(times[newest]) {
+			newest = v
+		}
+	}
+	return newest
+}
+
+func newestByAncestry(versions []*Version, repo *github.Repository, gh *github.GitHub) (*Version, error) {
+	newest := versions[0]
+	for _, v := range versions[1:] {
+		c, err := gh.CompareCommits(repo, newest.Revision, v.Revision)
+		if err != nil {
+			return nil, err
+		}
+		switch c.Status {
+		case "ahead":
+			newest = v
+		case "diverged":
+			return nil, fmt.Errorf("Revisions %s and %s diverged", newest.Revision, v.Revision)
+		}
+	}
+	return newest, nil
+}
+
+func humanDuration(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	if days > 0 {
+		return fmt.Sprintf("%d days", days)
+	}
+	return d.Round(time.Minute).String()
+}
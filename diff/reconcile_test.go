@@ -0,0 +1,106 @@
+package diff
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/radeksimko/go-mod-diff/github"
+)
+
+func TestReconcile(t *testing.T) {
+	older := &Version{
+		Version:    "1c05540f6879653db88113bc4a2b70aec4bd491f",
+		Revision:   "1c05540f6879653db88113bc4a2b70aec4bd491f",
+		Time:       "2018-04-13T09:12:43Z",
+		isRevision: true,
+		Packages:   []string{"golang.org/x/net/context", "golang.org/x/net/idna"},
+	}
+	newer := &Version{
+		Version:    "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+		Revision:   "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+		Time:       "2018-04-16T09:12:43Z",
+		isRevision: true,
+		Packages:   []string{"golang.org/x/net/http2"},
+	}
+	de := &DiffEntry{
+		ModulePath:       "golang.org/x/net",
+		GoModVersion:     &Version{Version: "v0.0.0-20180413091243-1c05540f6879"},
+		GoVendorVersions: []*Version{older, newer},
+	}
+
+	r, err := Reconcile(de, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Target != newer {
+		t.Fatalf("Expected target %s, given: %s", newer, r.Target)
+	}
+	if len(r.Moves) != 2 {
+		t.Fatalf("Expected 2 package moves, given: %d", len(r.Moves))
+	}
+	for i, pkg := range older.Packages {
+		m := r.Moves[i]
+		if m.Package != pkg || m.From != older || m.Behind != 72*time.Hour {
+			t.Fatalf("Unexpected move of %s: %s", pkg, m)
+		}
+	}
+}
+
+func TestReconcile_unknownTime(t *testing.T) {
+	de := &DiffEntry{
+		ModulePath:   "golang.org/x/net",
+		GoModVersion: &Version{Version: "v0.0.0-20180413091243-1c05540f6879"},
+		GoVendorVersions: []*Version{
+			{Version: "1c05540f6879", Revision: "1c05540f6879", isRevision: true},
+			{Version: "3b0461eec859", Revision: "3b0461eec859", isRevision: true},
+		},
+	}
+
+	_, err := Reconcile(de, nil)
+	if err == nil {
+		t.Fatal("Expected error for unknown revision times outside of GitHub")
+	}
+}
+
+func TestReconcile_compareFailed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintln(w, `{"message": "API rate limit exceeded"}`)
+	}))
+	defer ts.Close()
+
+	older := &Version{
+		Version:    "1c05540f6879653db88113bc4a2b70aec4bd491f",
+		Revision:   "1c05540f6879653db88113bc4a2b70aec4bd491f",
+		Time:       "2018-04-13T09:12:43Z",
+		isRevision: true,
+		Packages:   []string{"github.com/hashicorp/hcl/parser"},
+	}
+	newer := &Version{
+		Version:    "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+		Revision:   "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+		Time:       "2018-04-16T09:12:43Z",
+		isRevision: true,
+		Packages:   []string{"github.com/hashicorp/hcl/printer"},
+	}
+	de := &DiffEntry{
+		ModulePath:       "github.com/hashicorp/hcl",
+		GoModVersion:     &Version{Version: "v1.0.0"},
+		GoVendorVersions: []*Version{older, newer},
+	}
+
+	r, err := Reconcile(de, github.NewGitHubWithURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Target != newer {
+		t.Fatalf("Expected target %s, given: %s", newer, r.Target)
+	}
+	if len(r.Moves) != 1 || r.Moves[0].Comparison != nil || r.Moves[0].Behind != 72*time.Hour {
+		t.Fatalf("Expected move without comparison, given: %#v", r.Moves)
+	}
+}
@@ -0,0 +1,224 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/radeksimko/go-mod-diff/github"
+	"golang.org/x/mod/semver"
+)
+
+// TagSuggestion describes semver tags near a revision
+// which a pseudo-version requirement could be pinned to instead
+type TagSuggestion struct {
+	// Containing lists compared tags which contain the revision, sorted by semver
+	Containing []string     `json:"containing"`
+	Before     *TagDistance `json:"before,omitempty"`
+	After      *TagDistance `json:"after,omitempty"`
+
+	// Approximate is set if some tags failed to compare or tags sorted
+	// by semver don't follow the history, so closer tags may exist
+	Approximate bool `json:"approximate,omitempty"`
+
+	// compareErr is the last error of tags which failed to compare
+	compareErr error
+}
+
+// TagDistance is a tag and number of commits between it and the revision
+type TagDistance struct {
+	Tag     string `json:"tag"`
+	Commits int    `json:"commits"`
+}
+
+func (td *TagDistance) String() string {
+	if td.Commits == 0 {
+		return td.Tag
+	}
+	return fmt.Sprintf("%s (%d commits away)", td.Tag, td.Commits)
+}
+
+// Suggested returns the tag to pin to, preferring the closest tag
+// containing the revision, so that no code is lost by pinning to it
+func (ts *TagSuggestion) Suggested() *TagDistance {
+	if ts.After != nil {
+		return ts.After
+	}
+	return ts.Before
+}
+
+// SuggestTags finds nearest semver tags for every pseudo-version
+// requirement hosted on GitHub
+func SuggestTags(d *Diff, gh *github.GitHub) {
+	for _, bucket := range [][]*DiffEntry{d.Matched, d.NotFound, d.Different} {
+		for _, entry := range bucket {
+			if !entry.GoModVersion.IsRevision() {
+				continue
+			}
+
+			repo := entry.GithubRepository
+			if repo == nil {
+				r, err := github.ParseRepositoryURL(entry.ModulePath)
+				if err != nil {
+					continue
+				}
+				repo = r
+			}
+
+			ts, err := NearestTags(gh, repo, entry.GoModVersion.Revision)
+			if err != nil {
+				entry.Warnings = append(entry.Warnings, &Warning{
+					Kind:    WarningTagLookupFailed,
+					Message: fmt.Sprintf("Failed to find nearest tags: %s", err),
+				})
+				continue
+			}
+			if ts.compareErr != nil {
+				entry.Warnings = append(entry.Warnings, &Warning{
+					Kind:    WarningTagLookupFailed,
+					Message: fmt.Sprintf("Nearest tags are approximate, some tags failed to compare: %s", ts.compareErr),
+				})
+			}
+			entry.TagSuggestion = ts
+		}
+	}
+}
+
+// tagWindow is the number of tags compared on each side of the first tag
+// containing the revision, to find the closest ones among tags of the same commits
+const tagWindow = 2
+
+// NearestTags finds semver tags of the repository closest to the revision.
+// Tags sorted by semver are assumed to follow the history, so the first tag
+// containing the revision is found by bisection and only tags around it are compared.
+func NearestTags(gh *github.GitHub, repo *github.Repository, revision string) (*TagSuggestion, error) {
+	tags, err := gh.ListTags(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	comparisons := make(map[string]*github.Comparison, 0)
+	candidates := make([]*github.Tag, 0)
+	for _, tag := range tags {
+		if !semver.IsValid(tag.Name) {
+			continue
+		}
+		if strings.HasPrefix(tag.SHA, revision) {
+			comparisons[tag.Name] = &github.Comparison{Status: "identical"}
+			continue
+		}
+		candidates = append(candidates, tag)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return semver.Compare(candidates[i].Name, candidates[j].Name) < 0
+	})
+
+	var compareErr error
+	compare := func(tag *github.Tag) (*github.Comparison, error) {
+		if c, ok := comparisons[tag.Name]; ok {
+			return c, nil
+		}
+		c, err := gh.CompareCommits(repo, revision, tag.SHA)
+		if err != nil {
+			compareErr = err
+			return nil, err
+		}
+		comparisons[tag.Name] = c
+		return c, nil
+	}
+
+	// Tags which fail to compare are dropped
+	lo, hi := 0, len(candidates)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		c, err := compare(candidates[mid])
+		if err != nil {
+			candidates = append(candidates[:mid], candidates[mid+1:]...)
+			hi--
+			continue
+		}
+		if containsRevision(c) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	approximate := false
+	for i := lo - tagWindow; i < lo+tagWindow; i++ {
+		if i < 0 || i >= len(candidates) {
+			continue
+		}
+		c, err := compare(candidates[i])
+		if err != nil {
+			continue
+		}
+		if containsRevision(c) != (i >= lo) {
+			approximate = true
+		}
+	}
+
+	if len(comparisons) == 0 && compareErr != nil {
+		return nil, compareErr
+	}
+
+	ts := suggestTag(comparisons)
+	ts.Approximate = approximate || compareErr != nil
+	ts.compareErr = compareErr
+	return ts, nil
+}
+
+// containsRevision returns true if the tag (head) contains the revision (base)
+func containsRevision(c *github.Comparison) bool {
+	return c.Status == "ahead" || c.Status == "identical"
+}
+
+// suggestTag picks tags from comparisons of the revision (base) with tags (head)
+func suggestTag(comparisons map[string]*github.Comparison) *TagSuggestion {
+	ts := &TagSuggestion{
+		Containing: make([]string, 0),
+	}
+
+	for tag, c := range comparisons {
+		switch c.Status {
+		case "identical":
+			ts.Containing = append(ts.Containing, tag)
+			ts.After = closerTag(ts.After, &TagDistance{tag, 0})
+		case "ahead":
+			ts.Containing = append(ts.Containing, tag)
+			ts.After = closerTag(ts.After, &TagDistance{tag, c.AheadBy})
+		case "behind":
+			ts.Before = closerTag(ts.Before, &TagDistance{tag, c.BehindBy})
+		}
+	}
+
+	sort.Slice(ts.Containing, func(i, j int) bool {
+		return semver.Compare(ts.Containing[i], ts.Containing[j]) < 0
+	})
+
+	return ts
+}
+
+// closerTag returns the tag with fewer commits in between,
+// preferring releases over prereleases and then lower versions on a tie
+func closerTag(current, candidate *TagDistance) *TagDistance {
+	if current == nil || candidate.Commits < current.Commits {
+		return candidate
+	}
+	if candidate.Commits > current.Commits {
+		return current
+	}
+
+	candidatePre := semver.Prerelease(candidate.Tag) != ""
+	currentPre := semver.Prerelease(current.Tag) != ""
+	if candidatePre != currentPre {
+		if candidatePre {
+			return current
+		}
+		return candidate
+	}
+	if semver.Compare(candidate.Tag, current.Tag) < 0 {
+		return candidate
+	}
+	return current
+}
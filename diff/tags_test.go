@@ -0,0 +1,152 @@
+package diff
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/github"
+)
+
+func TestSuggestTag(t *testing.T) {
+	testCases := []struct {
+		comparisons        map[string]*github.Comparison
+		expectedSuggestion *TagSuggestion
+	}{
+		{
+			map[string]*github.Comparison{
+				"v1.0.0": {Status: "behind", BehindBy: 12},
+				"v1.1.0": {Status: "behind", BehindBy: 3},
+				"v1.2.0": {Status: "ahead", AheadBy: 5},
+				"v2.0.0": {Status: "ahead", AheadBy: 40},
+				"v0.9.0": {Status: "diverged", AheadBy: 2, BehindBy: 20},
+			},
+			&TagSuggestion{
+				Containing: []string{"v1.2.0", "v2.0.0"},
+				Before:     &TagDistance{"v1.1.0", 3},
+				After:      &TagDistance{"v1.2.0", 5},
+			},
+		},
+		{
+			map[string]*github.Comparison{
+				"v1.2.0-rc1": {Status: "identical"},
+				"v1.2.0":     {Status: "identical"},
+				"v1.1.0":     {Status: "behind", BehindBy: 7},
+			},
+			&TagSuggestion{
+				Containing: []string{"v1.2.0-rc1", "v1.2.0"},
+				Before:     &TagDistance{"v1.1.0", 7},
+				After:      &TagDistance{"v1.2.0", 0},
+			},
+		},
+		{
+			map[string]*github.Comparison{
+				"v0.1.0": {Status: "behind", BehindBy: 2},
+			},
+			&TagSuggestion{
+				Containing: []string{},
+				Before:     &TagDistance{"v0.1.0", 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		ts := suggestTag(tc.comparisons)
+		if !reflect.DeepEqual(tc.expectedSuggestion, ts) {
+			t.Fatalf("Expected %#v, given: %#v", tc.expectedSuggestion, ts)
+		}
+	}
+}
+
+func TestSuggestTags(t *testing.T) {
+	testCases := []struct {
+		failingTags        map[string]bool
+		expectedSuggestion *TagSuggestion
+		expectedCompared   int
+		expectedWarnings   int
+	}{
+		{
+			map[string]bool{},
+			&TagSuggestion{
+				Containing: []string{"v0.5.0", "v0.6.0", "v0.7.0", "v0.15.0"},
+				Before:     &TagDistance{"v0.4.0", 3},
+				After:      &TagDistance{"v0.5.0", 7},
+			},
+			6,
+			0,
+		},
+		{
+			map[string]bool{"v0.5.0": true},
+			&TagSuggestion{
+				Containing:  []string{"v0.6.0", "v0.7.0", "v0.15.0"},
+				Before:      &TagDistance{"v0.4.0", 3},
+				After:       &TagDistance{"v0.6.0", 17},
+				Approximate: true,
+			},
+			6,
+			1,
+		},
+	}
+
+	for i, tc := range testCases {
+		// Linear history with tag v0.N.0 at commit 10*N and the revision at commit 43,
+		// i.e. the nearest tags are far below the highest ones
+		compared := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			if r.URL.Path == "/repos/hashicorp/hcl/tags" {
+				tags := make([]string, 0)
+				for n := 0; n < 30; n++ {
+					tags = append(tags, fmt.Sprintf(`{"name": "v0.%d.0", "commit": {"sha": "%040d"}}`, n, n))
+				}
+				tags = append(tags, `{"name": "nightly", "commit": {"sha": "65a6292f0157eff210d03ed1bf6c59b190b8b906"}}`)
+				fmt.Fprintf(w, "[%s]", strings.Join(tags, ","))
+				return
+			}
+
+			compared++
+			n, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/repos/hashicorp/hcl/compare/8cb6e5b95923..."))
+			if err != nil || tc.failingTags[fmt.Sprintf("v0.%d.0", n)] {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintln(w, `{"message": "Not Found"}`)
+				return
+			}
+			if distance := 10*n - 43; distance > 0 {
+				fmt.Fprintf(w, `{"status": "ahead", "ahead_by": %d}`, distance)
+			} else {
+				fmt.Fprintf(w, `{"status": "behind", "behind_by": %d}`, -distance)
+			}
+		}))
+
+		entry := &DiffEntry{
+			ModulePath: "github.com/hashicorp/hcl",
+			GoModVersion: &Version{
+				Version:    "v0.0.0-20180413091243-8cb6e5b95923",
+				Revision:   "8cb6e5b95923",
+				isRevision: true,
+			},
+		}
+		SuggestTags(&Diff{Matched: []*DiffEntry{entry}}, github.NewGitHubWithURL(ts.URL))
+		ts.Close()
+
+		if compared != tc.expectedCompared {
+			t.Fatalf("%d: Expected %d tags to be compared, given: %d", i, tc.expectedCompared, compared)
+		}
+		entry.TagSuggestion.compareErr = nil
+		if !reflect.DeepEqual(tc.expectedSuggestion, entry.TagSuggestion) {
+			t.Fatalf("%d: Expected %#v, given: %#v", i, tc.expectedSuggestion, entry.TagSuggestion)
+		}
+		if len(entry.Warnings) != tc.expectedWarnings {
+			t.Fatalf("%d: Expected %d warnings, given: %#v", i, tc.expectedWarnings, entry.Warnings)
+		}
+		for _, w := range entry.Warnings {
+			if w.Kind != WarningTagLookupFailed {
+				t.Fatalf("%d: Expected tag lookup warning, given: %#v", i, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package fix
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Change pins a go.mod requirement to a govendor revision
+type Change struct {
+	ModulePath string
+	// From is the current go.mod version
+	From string
+	// To is the pseudo-version of Revision
+	To       string
+	Revision string
+	// Replace is path of the fork which replaces the module, if any
+	Replace string
+	// DropReplace is true if go.mod replaces the module
+	// and the replacement should be dropped
+	DropReplace bool
+	// ReplacedVersion is the version the existing replace directive
+	// applies to, empty if it applies to all versions
+	ReplacedVersion string
+	// Reason explains why the change is proposed
+	Reason string
+}
+
+func (c *Change) String() string {
+	if c.Replace != "" {
+		return fmt.Sprintf("%s %s => %s %s", c.ModulePath, c.From, c.Replace, c.To)
+	}
+	return fmt.Sprintf("%s %s => %s", c.ModulePath, c.From, c.To)
+}
+
+// Skipped is a difference which cannot be fixed automatically
+type Skipped struct {
+	ModulePath string
+	Reason     string
+}
+
+// Plan lists changes needed to pin go.mod to govendor revisions
+type Plan struct {
+	Changes []*Change
+	Skipped []*Skipped
+}
+
+// NewPlan proposes a change for every different or not found entry
+// which govendor pinned to a single revision
+func NewPlan(d *diff.Diff) *Plan {
+	p := &Plan{
+		Changes: make([]*Change, 0),
+		Skipped: make([]*Skipped, 0),
+	}
+
+	entries := append(append([]*diff.DiffEntry{}, d.Different...), d.NotFound...)
+	for _, entry := range entries {
+		c, err := planChange(entry)
+		if err != nil {
+			p.Skipped = append(p.Skipped, &Skipped{
+				ModulePath: entry.ModulePath,
+				Reason:     err.Error(),
+			})
+			continue
+		}
+		p.Changes = append(p.Changes, c)
+	}
+
+	return p
+}
+
+func planChange(de *diff.DiffEntry) (*Change, error) {
+	if len(de.GoVendorVersions) == 0 {
+		return nil, fmt.Errorf("not found in govendor")
+	}
+	if len(de.GoVendorVersions) > 1 {
+		return nil, fmt.Errorf("govendor pins %d different revisions", len(de.GoVendorVersions))
+	}
+
+	return PinToRevision(de, de.GoVendorVersions[0])
+}
+
+// PinToRevision returns change pinning the module to a (govendor) revision.
+// Revisions of forks are pinned via replace directive.
+func PinToRevision(de *diff.DiffEntry, v *diff.Version) (*Change, error) {
+	if v.Origin == "" && v.Tag != "" {
+		if c, err := PinToTag(de, v.Tag); err == nil {
+			c.Reason = fmt.Sprintf("govendor pins tag %s", v.Tag)
+			return c, nil
+		}
+	}
+
+	path := de.ModulePath
+	reason := fmt.Sprintf("govendor pins revision %s", v.String())
+	if v.Origin != "" {
+		path = v.Origin
+		reason = fmt.Sprintf("govendor pins revision %s of fork %s", v.Revision, v.Origin)
+	}
+
+	pv, err := PseudoVersion(path, v)
+	if err != nil {
+		return nil, err
+	}
+	// Pseudo-versions computed here are not based on any tag, so they sort
+	// below every tag and minimal version selection would keep the required one
+	// (replacements are not subject to version selection)
+	required := de.GoModVersion.Version
+	if v.Origin == "" && !baselessPseudoVersionRe.MatchString(required) && semver.Compare(pv, required) < 0 {
+		return nil, fmt.Errorf("pseudo-version %s sorts below required %s, pin via go get %s@%s instead",
+			pv, required, de.ModulePath, v.Revision)
+	}
+
+	return &Change{
+		ModulePath:      de.ModulePath,
+		From:            de.GoModVersion.Version,
+		To:              pv,
+		Revision:        v.Revision,
+		Replace:         v.Origin,
+		DropReplace:     v.Origin == "" && de.GoModReplacement != nil,
+		ReplacedVersion: de.GoModReplacedVersion,
+		Reason:          reason,
+	}, nil
+}
+
+// PinToTag returns change pinning the module to a semver tag
+func PinToTag(de *diff.DiffEntry, tag string) (*Change, error) {
+	if !semver.IsValid(tag) || semver.Canonical(tag)+semver.Build(tag) != tag {
+		return nil, fmt.Errorf("%q is not a canonical semver tag (e.g. v1.2.3)", tag)
+	}
+	_, pathMajor, ok := module.SplitPathVersion(de.ModulePath)
+	if ok {
+		err := module.CheckPathMajor(tag, pathMajor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Change{
+		ModulePath:      de.ModulePath,
+		From:            de.GoModVersion.Version,
+		To:              tag,
+		Revision:        tag,
+		DropReplace:     de.GoModReplacement != nil,
+		ReplacedVersion: de.GoModReplacedVersion,
+		Reason:          fmt.Sprintf("pinned to tag %s", tag),
+	}, nil
+}
+
+// baselessPseudoVersionRe matches pseudo-versions not based on any tag
+// (e.g. v0.0.0-20170808112155-b176d7def5d7), which sort by revision time
+var baselessPseudoVersionRe = regexp.MustCompile(`^v[0-9]+\.0\.0-[0-9]{14}-[0-9a-f]{12}$`)
+
+// PseudoVersion computes pseudo-version (e.g. v0.0.0-20170808112155-b176d7def5d7)
+// of a revision, with major version derived from module path
+func PseudoVersion(modulePath string, v *diff.Version) (string, error) {
+	if len(v.Revision) < 12 {
+		return "", fmt.Errorf("Revision %q is too short for a pseudo-version", v.Revision)
+	}
+	if v.Time == "" {
+		return "", fmt.Errorf("Time of revision %s is unknown", v.Revision)
+	}
+	t, err := time.Parse(time.RFC3339, v.Time)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse time of revision %s: %s", v.Revision, err)
+	}
+
+	major := "v0"
+	if _, pathMajor, ok := module.SplitPathVersion(modulePath); ok && pathMajor != "" {
+		major = module.PathMajorPrefix(pathMajor)
+	}
+
+	return fmt.Sprintf("%s.0.0-%s-%s", major,
+		t.UTC().Format("20060102150405"), v.Revision[0:12]), nil
+}
+
+// Apply applies all changes to f and returns the formatted go.mod.
+// Existing replace directives of specific versions are updated
+// (or dropped) in place, as they take precedence over other ones.
+func (p *Plan) Apply(f *modfile.File) ([]byte, error) {
+	for _, c := range p.Changes {
+		if c.Replace != "" {
+			err := f.AddReplace(c.ModulePath, c.ReplacedVersion, c.Replace, c.To)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if c.DropReplace {
+			err := f.DropReplace(c.ModulePath, c.ReplacedVersion)
+			if err != nil {
+				return nil, err
+			}
+		}
+		err := f.AddRequire(c.ModulePath, c.To)
+		if err != nil {
+			return nil, err
+		}
+	}
+	f.Cleanup()
+	return f.Format()
+}
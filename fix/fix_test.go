@@ -0,0 +1,419 @@
+package fix
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kardianos/govendor/vendorfile"
+	"github.com/radeksimko/go-mod-diff/diff"
+	"golang.org/x/mod/modfile"
+)
+
+func TestPseudoVersion(t *testing.T) {
+	testCases := []struct {
+		modulePath  string
+		version     *diff.Version
+		expected    string
+		expectedErr bool
+	}{
+		{
+			modulePath: "github.com/hashicorp/hcl",
+			version: &diff.Version{
+				Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241",
+				Time:     "2017-08-08T11:21:55Z",
+			},
+			expected: "v0.0.0-20170808112155-8cb6e5b95923",
+		},
+		{
+			modulePath: "github.com/hashicorp/hcl/v2",
+			version: &diff.Version{
+				Revision: "6743a2254ba3d642b7d3a0be506259a0842819df",
+				Time:     "2019-10-31T14:01:00-04:00",
+			},
+			expected: "v2.0.0-20191031180100-6743a2254ba3",
+		},
+		{
+			modulePath: "gopkg.in/yaml.v2",
+			version: &diff.Version{
+				Revision: "51d6538a90f86fe93ac480b35f37b2be17fef232",
+				Time:     "2018-11-15T11:05:04Z",
+			},
+			expected: "v2.0.0-20181115110504-51d6538a90f8",
+		},
+		{
+			modulePath: "github.com/hashicorp/hcl",
+			version: &diff.Version{
+				Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241",
+			},
+			expectedErr: true,
+		},
+		{
+			modulePath: "github.com/hashicorp/hcl",
+			version: &diff.Version{
+				Revision: "8cb6e5b",
+				Time:     "2017-08-08T11:21:55Z",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		pv, err := PseudoVersion(tc.modulePath, tc.version)
+		if err != nil {
+			if tc.expectedErr {
+				continue
+			}
+			t.Fatalf("%d: unexpected error: %s", i, err)
+		}
+		if tc.expectedErr {
+			t.Fatalf("%d: expected error, given: %s", i, pv)
+		}
+		if pv != tc.expected {
+			t.Fatalf("%d: expected %q, given: %q", i, tc.expected, pv)
+		}
+	}
+}
+
+func TestPlanApply(t *testing.T) {
+	d := &diff.Diff{
+		Different: []*diff.DiffEntry{
+			{
+				ModulePath:   "github.com/hashicorp/hcl",
+				GoModVersion: &diff.Version{Version: "v0.0.0-20180815150342-65a6292f0157"},
+				GoVendorVersions: []*diff.Version{
+					{
+						Version:  "8cb6e5b959231cc1119e43259c4a608f9c51a241",
+						Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241",
+						Time:     "2017-08-08T11:21:55Z",
+					},
+				},
+			},
+			{
+				ModulePath:   "golang.org/x/net",
+				GoModVersion: &diff.Version{Version: "v0.0.0-20180413091243-1c05540f6879"},
+				GoVendorVersions: []*diff.Version{
+					{Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f", Time: "2018-04-13T09:12:43Z"},
+					{Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938", Time: "2018-04-16T09:12:43Z"},
+				},
+			},
+		},
+		NotFound: []*diff.DiffEntry{
+			{
+				ModulePath:   "golang.org/x/xerrors",
+				GoModVersion: &diff.Version{Version: "v0.0.0-20191011141410-1b5146add898"},
+			},
+		},
+	}
+
+	p := NewPlan(d)
+	if len(p.Changes) != 1 {
+		t.Fatalf("Expected 1 change, given: %d", len(p.Changes))
+	}
+	if len(p.Skipped) != 2 {
+		t.Fatalf("Expected 2 skipped entries, given: %d", len(p.Skipped))
+	}
+
+	original := []byte(`module example.com/foo
+
+require (
+	github.com/hashicorp/hcl v0.0.0-20180815150342-65a6292f0157
+	golang.org/x/net v0.0.0-20180413091243-1c05540f6879
+	golang.org/x/xerrors v0.0.0-20191011141410-1b5146add898
+)
+`)
+	f, err := modfile.Parse("go.mod", original, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixed, err := p.Apply(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `--- a/go.mod
++++ b/go.mod
+@@ -1,7 +1,7 @@
+ module example.com/foo
+ 
+ require (
+-	github.com/hashicorp/hcl v0.0.0-20180815150342-65a6292f0157
++	github.com/hashicorp/hcl v0.0.0-20170808112155-8cb6e5b95923
+ 	golang.org/x/net v0.0.0-20180413091243-1c05540f6879
+ 	golang.org/x/xerrors v0.0.0-20191011141410-1b5146add898
+ )
+`
+	if given := UnifiedDiff("go.mod", original, fixed); given != expected {
+		t.Fatalf("Expected:\n%s\ngiven:\n%s", expected, given)
+	}
+}
+
+func TestPinToRevision(t *testing.T) {
+	revision := &diff.Version{
+		Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241",
+		Time:     "2017-08-08T11:21:55Z",
+	}
+	testCases := []struct {
+		modulePath  string
+		goModVer    string
+		version     *diff.Version
+		expectedTo  string
+		expectedErr bool
+	}{
+		{
+			"github.com/hashicorp/hcl",
+			"v0.0.0-20180815150342-65a6292f0157",
+			revision,
+			"v0.0.0-20170808112155-8cb6e5b95923",
+			false,
+		},
+		{ // pseudo-version would sort below the tag
+			"github.com/hashicorp/hcl",
+			"v1.0.0",
+			revision,
+			"",
+			true,
+		},
+		{ // base-less pseudo-versions sort by time
+			"github.com/hashicorp/terraform",
+			"v0.0.0-20190227065421-fc531f54a878",
+			revision,
+			"v0.0.0-20170808112155-8cb6e5b95923",
+			false,
+		},
+		{ // and below +incompatible tags
+			"github.com/hashicorp/go-getter",
+			"v2.1.0+incompatible",
+			revision,
+			"",
+			true,
+		},
+		{ // pseudo-version based on a tag sorts above any base-less one
+			"github.com/hashicorp/hcl",
+			"v1.0.1-0.20180815150342-65a6292f0157",
+			revision,
+			"",
+			true,
+		},
+		{ // revisions vendored by tag are pinned to the tag
+			"github.com/hashicorp/hcl",
+			"v1.0.0",
+			&diff.Version{
+				Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241",
+				Time:     "2017-08-08T11:21:55Z",
+				Tag:      "v0.1.0",
+			},
+			"v0.1.0",
+			false,
+		},
+		{ // forks are pinned via replace, not subject to version selection
+			"github.com/hashicorp/hcl",
+			"v1.0.0",
+			&diff.Version{
+				Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241",
+				Time:     "2017-08-08T11:21:55Z",
+				Origin:   "github.com/radeksimko/hcl",
+			},
+			"v0.0.0-20170808112155-8cb6e5b95923",
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		de := &diff.DiffEntry{
+			ModulePath:   tc.modulePath,
+			GoModVersion: &diff.Version{Version: tc.goModVer},
+		}
+		c, err := PinToRevision(de, tc.version)
+		if err != nil {
+			if tc.expectedErr {
+				continue
+			}
+			t.Fatalf("%d: unexpected error: %s", i, err)
+		}
+		if tc.expectedErr {
+			t.Fatalf("%d: expected error, given: %s", i, c)
+		}
+		if c.To != tc.expectedTo {
+			t.Fatalf("%d: expected %q, given: %q", i, tc.expectedTo, c.To)
+		}
+	}
+}
+
+func TestPinToTag(t *testing.T) {
+	testCases := []struct {
+		modulePath  string
+		tag         string
+		expectedErr bool
+	}{
+		{modulePath: "github.com/hashicorp/hcl", tag: "v1.0.0"},
+		{modulePath: "github.com/hashicorp/hcl", tag: "v1.0", expectedErr: true},
+		{modulePath: "github.com/hashicorp/hcl", tag: "master", expectedErr: true},
+		{modulePath: "github.com/hashicorp/hcl/v2", tag: "v2.3.0"},
+		{modulePath: "github.com/hashicorp/hcl/v2", tag: "v1.0.0", expectedErr: true},
+		{modulePath: "github.com/aws/aws-sdk-go", tag: "v1.25.3"},
+		{modulePath: "github.com/go-test/deep", tag: "v2.0.0", expectedErr: true},
+		{modulePath: "github.com/go-test/deep", tag: "v2.0.0+incompatible"},
+	}
+
+	for i, tc := range testCases {
+		de := &diff.DiffEntry{
+			ModulePath:   tc.modulePath,
+			GoModVersion: &diff.Version{Version: "v0.0.0-20170808112155-8cb6e5b95923"},
+		}
+		c, err := PinToTag(de, tc.tag)
+		if err != nil {
+			if tc.expectedErr {
+				continue
+			}
+			t.Fatalf("%d: unexpected error: %s", i, err)
+		}
+		if tc.expectedErr {
+			t.Fatalf("%d: expected error, given: %s", i, c)
+		}
+		if c.To != tc.tag {
+			t.Fatalf("%d: expected %q, given: %q", i, tc.tag, c.To)
+		}
+	}
+}
+
+func TestPlanApply_fork(t *testing.T) {
+	d := &diff.Diff{
+		Different: []*diff.DiffEntry{
+			{
+				ModulePath:   "github.com/hashicorp/hcl",
+				GoModVersion: &diff.Version{Version: "v1.0.0"},
+				GoVendorVersions: []*diff.Version{
+					{
+						Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241",
+						Time:     "2017-08-08T11:21:55Z",
+						Origin:   "github.com/radeksimko/hcl",
+					},
+				},
+			},
+			{
+				ModulePath:   "golang.org/x/net",
+				GoModVersion: &diff.Version{Version: "v0.0.0-20180101000000-aaaaaaaaaaaa"},
+				GoModReplacement: &diff.Version{
+					Version: "v0.0.0-20180413091243-1c05540f6879",
+					Origin:  "example.com/fork/net",
+				},
+				GoVendorVersions: []*diff.Version{
+					{Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938", Time: "2018-04-16T09:12:43Z"},
+				},
+			},
+		},
+	}
+
+	p := NewPlan(d)
+	if len(p.Changes) != 2 {
+		t.Fatalf("Expected 2 changes, given: %d", len(p.Changes))
+	}
+	if from := p.Changes[1].From; from != "v0.0.0-20180101000000-aaaaaaaaaaaa" {
+		t.Fatalf("Expected change from the required version, given: %q", from)
+	}
+
+	f, err := modfile.Parse("go.mod", []byte(`module example.com/foo
+
+require (
+	github.com/hashicorp/hcl v1.0.0
+	golang.org/x/net v0.0.0-20180101000000-aaaaaaaaaaaa
+)
+
+replace golang.org/x/net => example.com/fork/net v0.0.0-20180413091243-1c05540f6879
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixed, err := p.Apply(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `module example.com/foo
+
+require (
+	github.com/hashicorp/hcl v1.0.0
+	golang.org/x/net v0.0.0-20180416091243-3b0461eec859
+)
+
+replace github.com/hashicorp/hcl => github.com/radeksimko/hcl v0.0.0-20170808112155-8cb6e5b95923
+`
+	if string(fixed) != expected {
+		t.Fatalf("Expected:\n%s\ngiven:\n%s", expected, string(fixed))
+	}
+}
+
+func TestPlanApply_versionedReplace(t *testing.T) {
+	goMod := []byte(`module example.com/foo
+
+require (
+	golang.org/x/net v0.0.0-20180101000000-aaaaaaaaaaaa
+	golang.org/x/text v0.0.0-20180101000000-bbbbbbbbbbbb
+)
+
+replace golang.org/x/net v0.0.0-20180101000000-aaaaaaaaaaaa => example.com/fork/net v0.0.0-20180413091243-1c05540f6879
+
+replace golang.org/x/text v0.0.0-20180101000000-bbbbbbbbbbbb => example.com/fork/text v0.0.0-20180413091243-1c05540f6879
+`)
+	f, err := modfile.Parse("go.mod", goMod, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gvFile := &vendorfile.File{
+		Package: []*vendorfile.Package{
+			{
+				Path:         "golang.org/x/net/context",
+				Revision:     "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+				RevisionTime: "2018-04-16T09:12:43Z",
+			},
+			{
+				Path:         "golang.org/x/text/unicode",
+				Origin:       "example.com/fork/text/unicode",
+				Revision:     "f21a4dfb5e38f5895301dc265a8def02365cc3d0",
+				RevisionTime: "2018-04-16T09:12:43Z",
+			},
+		},
+	}
+	d, err := diff.CompareGoModWithGovendor(f, gvFile, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPlan(d)
+	if len(p.Changes) != 2 {
+		t.Fatalf("Expected 2 changes, given: %d (skipped: %#v)", len(p.Changes), p.Skipped)
+	}
+	fixed, err := p.Apply(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `module example.com/foo
+
+require (
+	golang.org/x/net v0.0.0-20180416091243-3b0461eec859
+	golang.org/x/text v0.0.0-20180101000000-bbbbbbbbbbbb
+)
+
+replace golang.org/x/text v0.0.0-20180101000000-bbbbbbbbbbbb => example.com/fork/text v0.0.0-20180416091243-f21a4dfb5e38
+`
+	if string(fixed) != expected {
+		t.Fatalf("Expected:\n%s\ngiven:\n%s", expected, string(fixed))
+	}
+
+	var buf bytes.Buffer
+	err = p.WriteScript(&buf, "go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"go mod edit -dropreplace=golang.org/x/net@v0.0.0-20180101000000-aaaaaaaaaaaa go.mod\n",
+		"go mod edit -replace=golang.org/x/text@v0.0.0-20180101000000-bbbbbbbbbbbb=" +
+			"example.com/fork/text@v0.0.0-20180416091243-f21a4dfb5e38 go.mod\n",
+	} {
+		if !strings.Contains(buf.String(), line) {
+			t.Fatalf("Expected script to contain %q, given:\n%s", line, buf.String())
+		}
+	}
+}
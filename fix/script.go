@@ -0,0 +1,57 @@
+package fix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// WriteScript writes a shell script which applies the plan
+// step by step via `go mod edit`, so it can be reviewed first
+func (p *Plan) WriteScript(w io.Writer, goModPath string) error {
+	bw := bufio.NewWriter(w)
+	quotedPath := shellQuote(goModPath)
+
+	fmt.Fprintf(bw, "#!/bin/sh\n")
+	fmt.Fprintf(bw, "# Pins %s requirements to govendor revisions, generated by go-mod-diff\n", quotedPath)
+	fmt.Fprintf(bw, "set -e\n")
+
+	for _, c := range p.Changes {
+		fmt.Fprintf(bw, "\n# %s: %s => %s\n", c.ModulePath, c.From, c.To)
+		fmt.Fprintf(bw, "# %s\n", c.Reason)
+		replaced := c.ModulePath
+		if c.ReplacedVersion != "" {
+			replaced += "@" + c.ReplacedVersion
+		}
+		if c.Replace != "" {
+			fmt.Fprintf(bw, "go mod edit -replace=%s=%s@%s %s\n", replaced, c.Replace, c.To, quotedPath)
+			continue
+		}
+		fmt.Fprintf(bw, "# (alternatively: go get %s@%s)\n", c.ModulePath, c.Revision)
+		if c.DropReplace {
+			fmt.Fprintf(bw, "go mod edit -dropreplace=%s %s\n", replaced, quotedPath)
+		}
+		fmt.Fprintf(bw, "go mod edit -require=%s@%s %s\n", c.ModulePath, c.To, quotedPath)
+	}
+
+	if len(p.Skipped) > 0 {
+		fmt.Fprintf(bw, "\n# Skipped, needs to be resolved manually:\n")
+		for _, s := range p.Skipped {
+			fmt.Fprintf(bw, "#  - %s: %s\n", s.ModulePath, s.Reason)
+		}
+	}
+
+	return bw.Flush()
+}
+
+var shellSafeRe = regexp.MustCompile(`^[A-Za-z0-9_./@:+=-]+$`)
+
+// shellQuote quotes s for use as a single shell word, if needed
+func shellQuote(s string) string {
+	if shellSafeRe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
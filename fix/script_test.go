@@ -0,0 +1,60 @@
+package fix
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPlanWriteScript(t *testing.T) {
+	p := &Plan{
+		Changes: []*Change{
+			{
+				ModulePath: "github.com/hashicorp/hcl",
+				From:       "v1.0.0",
+				To:         "v0.0.0-20170808112155-8cb6e5b95923",
+				Revision:   "8cb6e5b959231cc1119e43259c4a608f9c51a241",
+				Reason:     "govendor pins revision 8cb6e5b959231cc1119e43259c4a608f9c51a241 (2017-08-08T11:21:55Z)",
+			},
+		},
+		Skipped: []*Skipped{
+			{ModulePath: "golang.org/x/net", Reason: "govendor pins 2 different revisions"},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := p.WriteScript(&buf, "go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `#!/bin/sh
+# Pins go.mod requirements to govendor revisions, generated by go-mod-diff
+set -e
+
+# github.com/hashicorp/hcl: v1.0.0 => v0.0.0-20170808112155-8cb6e5b95923
+# govendor pins revision 8cb6e5b959231cc1119e43259c4a608f9c51a241 (2017-08-08T11:21:55Z)
+# (alternatively: go get github.com/hashicorp/hcl@8cb6e5b959231cc1119e43259c4a608f9c51a241)
+go mod edit -require=github.com/hashicorp/hcl@v0.0.0-20170808112155-8cb6e5b95923 go.mod
+
+# Skipped, needs to be resolved manually:
+#  - golang.org/x/net: govendor pins 2 different revisions
+`
+	if given := buf.String(); given != expected {
+		t.Fatalf("Expected:\n%s\ngiven:\n%s", expected, given)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	testCases := map[string]string{
+		"go.mod":                  "go.mod",
+		"/tmp/project/go.mod":     "/tmp/project/go.mod",
+		"/tmp/my project/go.mod":  "'/tmp/my project/go.mod'",
+		"/tmp/it's/go.mod":        `'/tmp/it'\''s/go.mod'`,
+		"/tmp/$(rm -rf ~)/go.mod": "'/tmp/$(rm -rf ~)/go.mod'",
+	}
+	for s, expected := range testCases {
+		if quoted := shellQuote(s); quoted != expected {
+			t.Fatalf("Expected %q to be quoted as %q, given: %q", s, expected, quoted)
+		}
+	}
+}
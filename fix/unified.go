@@ -0,0 +1,149 @@
+package fix
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// UnifiedDiff returns unified diff of two files (empty if equal)
+func UnifiedDiff(name string, a, b []byte) string {
+	lines := diffLines(splitLines(string(a)), splitLines(string(b)))
+
+	var out strings.Builder
+	for i := 0; i < len(lines); {
+		// find next change
+		for i < len(lines) && lines[i].op == ' ' {
+			i++
+		}
+		if i == len(lines) {
+			break
+		}
+
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		// extend hunk while changes are close to each other
+		end := i
+		for end < len(lines) {
+			if lines[end].op != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(lines) && lines[next].op == ' ' {
+				next++
+			}
+			if next == len(lines) || next-end > 2*diffContext {
+				end += diffContext
+				if end > len(lines) {
+					end = len(lines)
+				}
+				break
+			}
+			end = next
+		}
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", name, name)
+		}
+		writeHunk(&out, lines, start, end)
+		i = end
+	}
+
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, lines []diffLine, start, end int) {
+	aStart, bStart := 1, 1
+	for _, l := range lines[:start] {
+		if l.op != '+' {
+			aStart++
+		}
+		if l.op != '-' {
+			bStart++
+		}
+	}
+	aLen, bLen := 0, 0
+	for _, l := range lines[start:end] {
+		if l.op != '+' {
+			aLen++
+		}
+		if l.op != '-' {
+			bLen++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+	for _, l := range lines[start:end] {
+		fmt.Fprintf(out, "%c%s\n", l.op, l.text)
+	}
+}
+
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, length)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes line-based diff via longest common subsequence,
+// which is good enough for files as small as go.mod
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0)
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
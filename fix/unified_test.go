@@ -0,0 +1,33 @@
+package fix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	a := strings.Join([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}, "\n") + "\n"
+	b := strings.Join([]string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "twelve"}, "\n") + "\n"
+
+	expected := `--- a/f
++++ b/f
+@@ -1,3 +1,4 @@
++0
+ 1
+ 2
+ 3
+@@ -9,4 +10,4 @@
+ 9
+ 10
+ 11
+-12
++twelve
+`
+	if given := UnifiedDiff("f", []byte(a), []byte(b)); given != expected {
+		t.Fatalf("Expected:\n%s\ngiven:\n%s", expected, given)
+	}
+
+	if given := UnifiedDiff("f", []byte(a), []byte(a)); given != "" {
+		t.Fatalf("Expected no diff of equal files, given:\n%s", given)
+	}
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -13,8 +14,22 @@ import (
 const ghHostname = "github.com"
 
 type Repository struct {
-	Owner string
-	Name  string
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+}
+
+func (r *Repository) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// RepositoryStatus describes the state of a repository as reported by GitHub
+type RepositoryStatus struct {
+	// Canonical is the owner/name GitHub resolved the repository to,
+	// which differs from the requested one after a rename or transfer
+	Canonical *Repository
+	Archived  bool
+	// NotFound is true when the repository was deleted (or made private)
+	NotFound bool
 }
 
 type GitHub struct {
@@ -30,6 +45,74 @@ func (gh *GitHub) GetCommitSHA(r *Repository, ref string) (string, error) {
 	return *rc.SHA, nil
 }
 
+// GetRepositoryStatus looks up the canonical owner/name of the repository,
+// following redirects of renamed or transferred repositories
+func (gh *GitHub) GetRepositoryStatus(r *Repository) (*RepositoryStatus, error) {
+	repo, resp, err := gh.client.Repositories.Get(gh.ctx, r.Owner, r.Name)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return &RepositoryStatus{NotFound: true}, nil
+		}
+		return nil, err
+	}
+
+	return &RepositoryStatus{
+		Canonical: &Repository{
+			Owner: repo.GetOwner().GetLogin(),
+			Name:  repo.GetName(),
+		},
+		Archived: repo.GetArchived(),
+	}, nil
+}
+
+type Tag struct {
+	Name string
+	SHA  string
+}
+
+// ListTags returns all tags of the repository
+func (gh *GitHub) ListTags(r *Repository) ([]*Tag, error) {
+	tags := make([]*Tag, 0)
+	opt := &githubSDK.ListOptions{PerPage: 100}
+	for {
+		rTags, resp, err := gh.client.Repositories.ListTags(gh.ctx, r.Owner, r.Name, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range rTags {
+			tags = append(tags, &Tag{
+				Name: t.GetName(),
+				SHA:  t.GetCommit().GetSHA(),
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return tags, nil
+}
+
+// Comparison describes where head is in relation to base
+type Comparison struct {
+	// Status is one of "identical", "ahead", "behind" or "diverged"
+	Status   string `json:"status"`
+	AheadBy  int    `json:"ahead_by"`
+	BehindBy int    `json:"behind_by"`
+}
+
+func (gh *GitHub) CompareCommits(r *Repository, base, head string) (*Comparison, error) {
+	cc, _, err := gh.client.Repositories.CompareCommits(gh.ctx, r.Owner, r.Name, base, head)
+	if err != nil {
+		return nil, err
+	}
+	return &Comparison{
+		Status:   cc.GetStatus(),
+		AheadBy:  cc.GetAheadBy(),
+		BehindBy: cc.GetBehindBy(),
+	}, nil
+}
+
 func NewGitHub() *GitHub {
 	return &GitHub{
 		ctx:    context.Background(),
@@ -97,3 +180,8 @@ func TreeURL(repo *Repository, ref string) string {
 	return fmt.Sprintf("https://%s/%s/%s/tree/%s",
 		ghHostname, repo.Owner, repo.Name, ref)
 }
+
+func CompareURL(repo *Repository, base, head string) string {
+	return fmt.Sprintf("https://%s/%s/%s/compare/%s...%s",
+		ghHostname, repo.Owner, repo.Name, base, head)
+}
@@ -45,6 +45,139 @@ func TestGitHubGetCommitSHA(t *testing.T) {
 	}
 }
 
+func TestGitHubGetRepositoryStatus(t *testing.T) {
+	ts := githubApiMockServer([]*githubResponse{
+		{
+			URI:         "/repos/Sirupsen/logrus",
+			ContentType: "application/json; charset=utf-8",
+			Body: `{
+  "name": "logrus",
+  "full_name": "sirupsen/logrus",
+  "owner": {
+    "login": "sirupsen"
+  },
+  "archived": false
+}`,
+		},
+		{
+			URI:         "/repos/hashicorp/hcl2",
+			ContentType: "application/json; charset=utf-8",
+			Body: `{
+  "name": "hcl2",
+  "full_name": "hashicorp/hcl2",
+  "owner": {
+    "login": "hashicorp"
+  },
+  "archived": true
+}`,
+		},
+		{
+			URI:         "/repos/hashicorp/deleted",
+			StatusCode:  404,
+			ContentType: "application/json; charset=utf-8",
+			Body: `{
+  "message": "Not Found",
+  "documentation_url": "https://developer.github.com/v3/repos/#get"
+}`,
+		},
+	})
+	defer ts.Close()
+
+	testCases := []struct {
+		repo           *Repository
+		expectedStatus *RepositoryStatus
+	}{
+		{
+			&Repository{"Sirupsen", "logrus"},
+			&RepositoryStatus{Canonical: &Repository{"sirupsen", "logrus"}},
+		},
+		{
+			&Repository{"hashicorp", "hcl2"},
+			&RepositoryStatus{Canonical: &Repository{"hashicorp", "hcl2"}, Archived: true},
+		},
+		{
+			&Repository{"hashicorp", "deleted"},
+			&RepositoryStatus{NotFound: true},
+		},
+	}
+
+	gh := NewGitHubWithURL(ts.URL)
+	for _, tc := range testCases {
+		status, err := gh.GetRepositoryStatus(tc.repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(tc.expectedStatus, status) {
+			t.Fatalf("Expected %#v, given: %#v", tc.expectedStatus, status)
+		}
+	}
+}
+
+func TestGitHubListTags(t *testing.T) {
+	ts := githubApiMockServer([]*githubResponse{
+		{
+			URI:         "/repos/hashicorp/hcl/tags?per_page=100",
+			ContentType: "application/json; charset=utf-8",
+			Body: `[
+  {
+    "name": "v1.0.0",
+    "commit": {
+      "sha": "8cb6e5b959231cc1119e43259c4a608f9c51a241"
+    }
+  },
+  {
+    "name": "v0.1.0",
+    "commit": {
+      "sha": "65a6292f0157eff210d03ed1bf6c59b190b8b906"
+    }
+  }
+]`,
+		},
+	})
+	defer ts.Close()
+
+	gh := NewGitHubWithURL(ts.URL)
+	tags, err := gh.ListTags(&Repository{"hashicorp", "hcl"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTags := []*Tag{
+		{"v1.0.0", "8cb6e5b959231cc1119e43259c4a608f9c51a241"},
+		{"v0.1.0", "65a6292f0157eff210d03ed1bf6c59b190b8b906"},
+	}
+	if !reflect.DeepEqual(expectedTags, tags) {
+		t.Fatalf("Expected %#v, given: %#v", expectedTags, tags)
+	}
+}
+
+func TestGitHubCompareCommits(t *testing.T) {
+	ts := githubApiMockServer([]*githubResponse{
+		{
+			URI:         "/repos/hashicorp/hcl/compare/65a6292f0157...v1.0.0",
+			ContentType: "application/json; charset=utf-8",
+			Body: `{
+  "status": "ahead",
+  "ahead_by": 42,
+  "behind_by": 0,
+  "total_commits": 42
+}`,
+		},
+	})
+	defer ts.Close()
+
+	gh := NewGitHubWithURL(ts.URL)
+	c, err := gh.CompareCommits(&Repository{"hashicorp", "hcl"}, "65a6292f0157", "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Comparison{Status: "ahead", AheadBy: 42}
+	if !reflect.DeepEqual(expected, c) {
+		t.Fatalf("Expected %#v, given: %#v", expected, c)
+	}
+}
+
 func TestParseRepositoryURL(t *testing.T) {
 	testCases := []struct {
 		rawURL       string
@@ -132,14 +265,24 @@ func TestTreeURL(t *testing.T) {
 	}
 }
 
+func TestCompareURL(t *testing.T) {
+	url := CompareURL(&Repository{"hashicorp", "terraform"}, "v0.11.11", "f9b62cb5fef7")
+	expectedUrl := "https://github.com/hashicorp/terraform/compare/v0.11.11...f9b62cb5fef7"
+	if url != expectedUrl {
+		t.Fatalf("Expected %q, given: %q", expectedUrl, url)
+	}
+}
+
 func githubApiMockServer(reponses []*githubResponse) *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("[DEBUG] Mock server received request to %q", r.RequestURI)
 		for _, resp := range reponses {
 			if r.RequestURI == resp.URI {
 				w.Header().Set("Content-Type", resp.ContentType)
+				if resp.StatusCode != 0 {
+					w.WriteHeader(resp.StatusCode)
+				}
 				fmt.Fprintln(w, resp.Body)
-				w.WriteHeader(200)
 				return
 			}
 		}
@@ -149,6 +292,7 @@ func githubApiMockServer(reponses []*githubResponse) *httptest.Server {
 
 type githubResponse struct {
 	URI         string
+	StatusCode  int
 	ContentType string
 	Body        string
 }
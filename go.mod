@@ -4,9 +4,11 @@ go 1.14
 
 require (
 	github.com/google/go-github/v22 v22.0.0
+	github.com/hashicorp/hcl v1.0.0
 	github.com/kardianos/govendor v1.0.9
 	github.com/mitchellh/colorstring v0.0.0-20150917214807-8631ce90f286
 	golang.org/x/mod v0.2.0
 	golang.org/x/oauth2 v0.0.0-20180821212333-d2e6202438be
+	golang.org/x/term v0.0.0-20201126162022-7de9c90e9dd1
 	golang.org/x/tools v0.0.0-20191119224855-298f0cb1881e
 )
@@ -0,0 +1,71 @@
+package gomod
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// ModuleCache is the local module cache ($GOMODCACHE, $GOPATH/pkg/mod by default)
+type ModuleCache struct {
+	Dir string
+}
+
+// FindModuleCache locates the module cache via go env
+func FindModuleCache() (*ModuleCache, error) {
+	cmd := exec.Command("go", "env", "GOMODCACHE", "GOPATH")
+	var stdout, stderr bytes.Buffer
+	cmd.Env = append(os.Environ(), "GO111MODULE=on")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to run go env: %s\n%s", err, stderr.String())
+	}
+
+	// Go versions before 1.15 print empty line for unknown GOMODCACHE
+	lines := strings.Split(strings.TrimRight(stdout.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		return nil, fmt.Errorf("Unexpected output of go env: %q", stdout.String())
+	}
+	if lines[0] != "" {
+		return &ModuleCache{Dir: lines[0]}, nil
+	}
+	gopath := filepath.SplitList(lines[1])
+	if len(gopath) == 0 || gopath[0] == "" {
+		return nil, fmt.Errorf("Neither GOMODCACHE nor GOPATH is set")
+	}
+	return &ModuleCache{Dir: filepath.Join(gopath[0], "pkg", "mod")}, nil
+}
+
+// ModuleDir returns directory with extracted contents of the module version
+func (mc *ModuleCache) ModuleDir(mv module.Version) (string, error) {
+	path, err := module.EscapePath(mv.Path)
+	if err != nil {
+		return "", err
+	}
+	version, err := module.EscapeVersion(mv.Version)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(mc.Dir, filepath.FromSlash(path)+"@"+version), nil
+}
+
+// DownloadFile returns path of the downloaded file of the module version
+// with given extension, e.g. ".zip" or ".mod"
+func (mc *ModuleCache) DownloadFile(mv module.Version, ext string) (string, error) {
+	path, err := module.EscapePath(mv.Path)
+	if err != nil {
+		return "", err
+	}
+	version, err := module.EscapeVersion(mv.Version)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(mc.Dir, "cache", "download", filepath.FromSlash(path), "@v", version+ext), nil
+}
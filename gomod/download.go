@@ -0,0 +1,67 @@
+package gomod
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+
+	"golang.org/x/mod/module"
+)
+
+// DownloadedModule is a module as reported by `go mod download -json`
+type DownloadedModule struct {
+	Path     string
+	Version  string
+	Error    string
+	Dir      string
+	Sum      string
+	GoModSum string
+}
+
+// DownloadModules ensures modules are in the module cache (downloading them
+// via GOPROXY if needed) and returns their details keyed by path@version
+func DownloadModules(dir string, mods []module.Version) (map[string]*DownloadedModule, string, error) {
+	args := []string{"mod", "download", "-json"}
+	for _, mv := range mods {
+		args = append(args, mv.String())
+	}
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Env = append(os.Environ(), "GO111MODULE=on")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// Failure of any module makes go mod download fail,
+	// but details of all modules (including errors) are still printed
+	runErr := cmd.Run()
+
+	downloaded, err := parseDownloadedModules(&stdout)
+	if err != nil {
+		return nil, stderr.String(), err
+	}
+	if runErr != nil && len(downloaded) == 0 {
+		return nil, stderr.String(), runErr
+	}
+	return downloaded, "", nil
+}
+
+func parseDownloadedModules(r io.Reader) (map[string]*DownloadedModule, error) {
+	downloaded := make(map[string]*DownloadedModule, 0)
+	dec := json.NewDecoder(r)
+	for {
+		var dm DownloadedModule
+		err := dec.Decode(&dm)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		downloaded[module.Version{Path: dm.Path, Version: dm.Version}.String()] = &dm
+	}
+	return downloaded, nil
+}
@@ -1,15 +1,13 @@
 package gomod
 
 import (
-	"bytes"
 	"fmt"
 	"io/ioutil"
-	"os"
-	"os/exec"
 	"regexp"
 	"strings"
 
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
 	"golang.org/x/tools/go/vcs"
 )
 
@@ -71,73 +69,37 @@ func ParseRefFromVersion(rawVersion string) (*VersionRef, error) {
 	return &VersionRef{rawVersion, false}, nil
 }
 
-func GoModWhy(importPath string) ([][]string, string, error) {
-	cmd := exec.Command("go", "mod", "why", "-m", importPath)
-	var stdout, stderr bytes.Buffer
-	cmd.Env = append(os.Environ(), "GO111MODULE=on")
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
+func repoRootForImportPath(importPath string) (string, error) {
+	rr, err := vcs.RepoRootForImportPath(importPath, false)
 	if err != nil {
-		return nil, stderr.String(), err
+		return "", err
 	}
+	return rr.Root, nil
+}
 
-	capture := false
-	moduleTrees := make([][]string, 0)
-	tree := make([]string, 0)
-	i, j := 0, 0
-	lastCapturedRoot := ""
-	for {
-		line, err := stdout.ReadString('\n')
-
-		// beginning of tree
-		if strings.HasPrefix(line, "# ") {
-			capture = true
-			j = 0
-			continue
-		}
+// RequiredPaths returns paths of all modules required in go.mod
+func RequiredPaths(goModFile *modfile.File) []string {
+	required := make([]string, 0, len(goModFile.Require))
+	for _, r := range goModFile.Require {
+		required = append(required, r.Mod.Path)
+	}
+	return required
+}
 
-		// end of tree
-		if err != nil || line == "\n" || strings.Contains(line, "module does not need") {
-			capture = false
-			lastCapturedRoot = ""
-			// Save tree, if there's anything to save
-			if len(tree) > 0 {
-				moduleTrees = append(moduleTrees, tree)
-				tree = make([]string, 0)
-				i++
-			}
-			if err != nil {
-				break
-			}
+// FindReplace returns replace directive which applies to the module version, if any
+func FindReplace(goModFile *modfile.File, mv module.Version) *modfile.Replace {
+	var found *modfile.Replace
+	for _, r := range goModFile.Replace {
+		if r.Old.Path != mv.Path {
 			continue
 		}
-
-		line = strings.TrimSpace(line)
-
-		if capture {
-			if j == 0 {
-				tree = append(tree, line)
-			} else {
-				repoRoot, _ := repoRootForImportPath(line)
-				if repoRoot != lastCapturedRoot {
-					// log.Printf("[%d] %q", j, repoRoot)
-					tree = append(tree, repoRoot)
-					lastCapturedRoot = repoRoot
-				}
-			}
-			j++
+		// Replacement of the specific version takes precedence
+		if r.Old.Version == mv.Version {
+			return r
+		}
+		if r.Old.Version == "" {
+			found = r
 		}
 	}
-
-	return moduleTrees, "", nil
-}
-
-func repoRootForImportPath(importPath string) (string, error) {
-	rr, err := vcs.RepoRootForImportPath(importPath, false)
-	if err != nil {
-		return "", err
-	}
-	return rr.Root, nil
+	return found
 }
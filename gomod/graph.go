@@ -0,0 +1,192 @@
+package gomod
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// ImportGraph is the graph of packages imported (directly, transitively
+// or by tests) by packages of the main module
+type ImportGraph struct {
+	mainModule string
+	roots      []string
+	imports    map[string][]string
+	// modules maps packages to paths of modules which provide them
+	modules map[string]string
+
+	// Resolver is used for packages which go list reported without module
+	Resolver *ModuleResolver
+}
+
+type listPackage struct {
+	ImportPath string
+	Standard   bool
+	DepOnly    bool
+	Imports    []string
+	Module     *struct {
+		Path string
+		Main bool
+	}
+}
+
+// LoadImportGraph lists packages of the main module in dir along with
+// all their dependencies via single `go list -deps -test` invocation
+func LoadImportGraph(dir string) (*ImportGraph, string, error) {
+	cmd := exec.Command("go", "list", "-e", "-deps", "-test", "-json", "./...")
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Env = append(os.Environ(), "GO111MODULE=on")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return nil, stderr.String(), err
+	}
+
+	g, err := parseImportGraph(&stdout)
+	if err != nil {
+		return nil, "", err
+	}
+	return g, "", nil
+}
+
+func parseImportGraph(r io.Reader) (*ImportGraph, error) {
+	g := &ImportGraph{
+		roots:   make([]string, 0),
+		imports: make(map[string][]string, 0),
+		modules: make(map[string]string, 0),
+	}
+	isRoot := make(map[string]bool, 0)
+
+	dec := json.NewDecoder(r)
+	for {
+		var pkg listPackage
+		err := dec.Decode(&pkg)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Standard library cannot import modules
+		if pkg.Standard {
+			continue
+		}
+
+		// Test variants (e.g. "foo [foo.test]") are merged with packages
+		path := packagePath(pkg.ImportPath)
+		for _, imp := range pkg.Imports {
+			g.imports[path] = append(g.imports[path], packagePath(imp))
+		}
+
+		if pkg.Module == nil {
+			continue
+		}
+		g.modules[path] = pkg.Module.Path
+		if pkg.Module.Main {
+			g.mainModule = pkg.Module.Path
+			if !pkg.DepOnly && !isRoot[path] {
+				isRoot[path] = true
+				g.roots = append(g.roots, path)
+			}
+		}
+	}
+
+	sort.Strings(g.roots)
+	return g, nil
+}
+
+func packagePath(importPath string) string {
+	if i := strings.Index(importPath, " ["); i != -1 {
+		return importPath[:i]
+	}
+	return importPath
+}
+
+// ModuleOf returns path of the module providing the package
+// or empty string if it is not known
+func (g *ImportGraph) ModuleOf(pkgPath string) string {
+	if mod, ok := g.modules[pkgPath]; ok {
+		return mod
+	}
+	if g.Resolver != nil {
+		mod, err := g.Resolver.ModuleOf(pkgPath)
+		if err == nil {
+			return mod
+		}
+	}
+	return ""
+}
+
+// ModuleWhy returns the shortest import chain from a package of the main module
+// to a package of the given module (as `go mod why -m` would) or nil if the module
+// is not needed. The first element is the main module package,
+// the rest are paths of modules the chain goes through.
+func (g *ImportGraph) ModuleWhy(modulePath string) []string {
+	parents := make(map[string]string, 0)
+	visited := make(map[string]bool, 0)
+	queue := make([]string, 0, len(g.roots))
+	for _, root := range g.roots {
+		visited[root] = true
+		queue = append(queue, root)
+	}
+
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+
+		if g.inModule(pkg, modulePath) {
+			return g.moduleChain(pkg, parents)
+		}
+
+		for _, imp := range g.imports[pkg] {
+			if visited[imp] {
+				continue
+			}
+			visited[imp] = true
+			parents[imp] = pkg
+			queue = append(queue, imp)
+		}
+	}
+
+	return nil
+}
+
+func (g *ImportGraph) inModule(pkgPath, modulePath string) bool {
+	if mod, ok := g.modules[pkgPath]; ok {
+		return mod == modulePath
+	}
+	return pkgPath == modulePath || strings.HasPrefix(pkgPath, modulePath+"/")
+}
+
+// moduleChain collapses package chain ending with pkg into module paths
+func (g *ImportGraph) moduleChain(pkg string, parents map[string]string) []string {
+	pkgs := []string{pkg}
+	for {
+		parent, ok := parents[pkg]
+		if !ok {
+			break
+		}
+		pkgs = append([]string{parent}, pkgs...)
+		pkg = parent
+	}
+
+	chain := []string{pkgs[0]}
+	last := g.mainModule
+	for _, p := range pkgs[1:] {
+		mod := g.ModuleOf(p)
+		if mod == "" || mod == last {
+			continue
+		}
+		chain = append(chain, mod)
+		last = mod
+	}
+	return chain
+}
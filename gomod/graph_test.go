@@ -0,0 +1,80 @@
+package gomod
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testGoListOutput = `{
+	"ImportPath": "fmt",
+	"Standard": true,
+	"Imports": ["errors"]
+}
+{
+	"ImportPath": "github.com/hashicorp/hcl/hcl/ast",
+	"Module": {"Path": "github.com/hashicorp/hcl", "Version": "v1.0.0"},
+	"Imports": ["fmt"]
+}
+{
+	"ImportPath": "github.com/hashicorp/hcl",
+	"Module": {"Path": "github.com/hashicorp/hcl", "Version": "v1.0.0"},
+	"Imports": ["github.com/hashicorp/hcl/hcl/ast"]
+}
+{
+	"ImportPath": "github.com/hashicorp/terraform/config",
+	"Module": {"Path": "github.com/hashicorp/terraform", "Main": true},
+	"Imports": ["github.com/hashicorp/hcl"]
+}
+{
+	"ImportPath": "github.com/stretchr/testify/assert",
+	"Module": {"Path": "github.com/stretchr/testify", "Version": "v1.4.0"},
+	"Imports": ["fmt"]
+}
+{
+	"ImportPath": "github.com/hashicorp/terraform/command [github.com/hashicorp/terraform/command.test]",
+	"Module": {"Path": "github.com/hashicorp/terraform", "Main": true},
+	"Imports": ["github.com/hashicorp/terraform/config", "github.com/stretchr/testify/assert"]
+}
+{
+	"ImportPath": "github.com/hashicorp/terraform/command",
+	"Module": {"Path": "github.com/hashicorp/terraform", "Main": true},
+	"Imports": ["github.com/hashicorp/terraform/config"]
+}
+{
+	"ImportPath": "github.com/hashicorp/terraform/command.test",
+	"Imports": ["github.com/hashicorp/terraform/command [github.com/hashicorp/terraform/command.test]"]
+}
+`
+
+func TestImportGraphModuleWhy(t *testing.T) {
+	g, err := parseImportGraph(strings.NewReader(testGoListOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		modulePath    string
+		expectedChain []string
+	}{
+		{
+			"github.com/hashicorp/hcl",
+			[]string{"github.com/hashicorp/terraform/config", "github.com/hashicorp/hcl"},
+		},
+		{
+			"github.com/stretchr/testify",
+			[]string{"github.com/hashicorp/terraform/command", "github.com/stretchr/testify"},
+		},
+		{
+			"github.com/hashicorp/hcl2",
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		chain := g.ModuleWhy(tc.modulePath)
+		if !reflect.DeepEqual(tc.expectedChain, chain) {
+			t.Fatalf("%s: expected %q, given: %q", tc.modulePath, tc.expectedChain, chain)
+		}
+	}
+}
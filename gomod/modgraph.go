@@ -0,0 +1,152 @@
+package gomod
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// ModuleGraph is the module requirement graph as printed by `go mod graph`
+type ModuleGraph struct {
+	// requirements maps module paths to requirements of them
+	requirements map[string][]*Requirement
+}
+
+// Requirement is a requirement of a specific module version by a dependent module
+type Requirement struct {
+	DependentPath string
+	// DependentVersion is empty for the main module
+	DependentVersion string
+	Version          string
+}
+
+func (r *Requirement) Dependent() string {
+	if r.DependentVersion == "" {
+		return r.DependentPath
+	}
+	return r.DependentPath + "@" + r.DependentVersion
+}
+
+// LoadModuleGraph runs `go mod graph` in the given directory of the main module
+func LoadModuleGraph(dir string) (*ModuleGraph, string, error) {
+	cmd := exec.Command("go", "mod", "graph")
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Env = append(os.Environ(), "GO111MODULE=on")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return nil, stderr.String(), err
+	}
+
+	g, err := parseModuleGraph(&stdout)
+	if err != nil {
+		return nil, "", err
+	}
+	return g, "", nil
+}
+
+func parseModuleGraph(r io.Reader) (*ModuleGraph, error) {
+	g := &ModuleGraph{
+		requirements: make(map[string][]*Requirement, 0),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("Unexpected line in go mod graph: %q", line)
+		}
+
+		depPath, depVersion := splitModuleVersion(fields[0])
+		path, version := splitModuleVersion(fields[1])
+		// Newer Go versions also list go and toolchain version requirements
+		if path == "go" || path == "toolchain" {
+			continue
+		}
+
+		g.requirements[path] = append(g.requirements[path], &Requirement{
+			DependentPath:    depPath,
+			DependentVersion: depVersion,
+			Version:          version,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func splitModuleVersion(s string) (string, string) {
+	i := strings.Index(s, "@")
+	if i == -1 {
+		return s, ""
+	}
+	return s[:i], s[i+1:]
+}
+
+// SelectedVersion returns the version minimal version selection picks,
+// i.e. the highest of all required versions
+func (g *ModuleGraph) SelectedVersion(modulePath string) string {
+	selected := ""
+	for _, r := range g.requirements[modulePath] {
+		if selected == "" || semver.Compare(r.Version, selected) > 0 {
+			selected = r.Version
+		}
+	}
+	return selected
+}
+
+// Requirements returns all requirements of the module grouped by required version
+func (g *ModuleGraph) Requirements(modulePath string) map[string][]*Requirement {
+	byVersion := make(map[string][]*Requirement, 0)
+	for _, r := range g.requirements[modulePath] {
+		byVersion[r.Version] = append(byVersion[r.Version], r)
+	}
+	for _, reqs := range byVersion {
+		sort.Slice(reqs, func(i, j int) bool {
+			return reqs[i].Dependent() < reqs[j].Dependent()
+		})
+	}
+	return byVersion
+}
+
+// MainRequirement returns requirement of the module by the main module, if any
+func (g *ModuleGraph) MainRequirement(modulePath string) *Requirement {
+	for _, r := range g.requirements[modulePath] {
+		if r.DependentVersion == "" {
+			return r
+		}
+	}
+	return nil
+}
+
+// Versions returns all required versions of the module, highest first
+func (g *ModuleGraph) Versions(modulePath string) []string {
+	versions := make([]string, 0)
+	seen := make(map[string]bool, 0)
+	for _, r := range g.requirements[modulePath] {
+		if !seen[r.Version] {
+			seen[r.Version] = true
+			versions = append(versions, r.Version)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return semver.Compare(versions[i], versions[j]) > 0
+	})
+	return versions
+}
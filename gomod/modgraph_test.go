@@ -0,0 +1,62 @@
+package gomod
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testGoModGraphOutput = `example.com/proj go@1.14
+example.com/proj golang.org/x/net@v0.0.0-20190404232315-eb5bcb51f2a3
+example.com/proj golang.org/x/tools@v0.0.0-20191119224855-298f0cb1881e
+golang.org/x/crypto@v0.0.0-20191011191535-87dc89f01550 golang.org/x/net@v0.0.0-20190404232315-eb5bcb51f2a3
+golang.org/x/tools@v0.0.0-20191119224855-298f0cb1881e golang.org/x/net@v0.0.0-20190620200207-3b0461eec859
+`
+
+func TestModuleGraph(t *testing.T) {
+	g, err := parseModuleGraph(strings.NewReader(testGoModGraphOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := "golang.org/x/net"
+	expectedVersions := []string{
+		"v0.0.0-20190620200207-3b0461eec859",
+		"v0.0.0-20190404232315-eb5bcb51f2a3",
+	}
+	if versions := g.Versions(path); !reflect.DeepEqual(expectedVersions, versions) {
+		t.Fatalf("Expected versions %q, given: %q", expectedVersions, versions)
+	}
+	if selected := g.SelectedVersion(path); selected != expectedVersions[0] {
+		t.Fatalf("Expected selected version %q, given: %q", expectedVersions[0], selected)
+	}
+
+	reqs := g.Requirements(path)
+	dependents := make([]string, 0)
+	for _, r := range reqs["v0.0.0-20190404232315-eb5bcb51f2a3"] {
+		dependents = append(dependents, r.Dependent())
+	}
+	expectedDependents := []string{
+		"example.com/proj",
+		"golang.org/x/crypto@v0.0.0-20191011191535-87dc89f01550",
+	}
+	if !reflect.DeepEqual(expectedDependents, dependents) {
+		t.Fatalf("Expected dependents %q, given: %q", expectedDependents, dependents)
+	}
+
+	mr := g.MainRequirement(path)
+	if mr == nil || mr.Version != "v0.0.0-20190404232315-eb5bcb51f2a3" {
+		t.Fatalf("Unexpected main module requirement: %#v", mr)
+	}
+
+	if len(g.Versions("go")) != 0 {
+		t.Fatal("Expected go version requirement to be ignored")
+	}
+}
+
+func TestParseModuleGraph_invalid(t *testing.T) {
+	_, err := parseModuleGraph(strings.NewReader("example.com/proj\n"))
+	if err == nil {
+		t.Fatal("Expected error for malformed line")
+	}
+}
@@ -0,0 +1,105 @@
+package gomod
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// ModuleResolver maps import paths to paths of modules which provide them,
+// using go.mod and the local module graph and resorting to network
+// discovery (via go-import meta tags) only when the module is unknown locally
+type ModuleResolver struct {
+	dir      string
+	required []string
+
+	listed       []string
+	listedLoaded bool
+
+	cache map[string]string
+}
+
+func NewModuleResolver(goModFile *modfile.File) *ModuleResolver {
+	mr := &ModuleResolver{
+		dir:      filepath.Dir(goModFile.Syntax.Name),
+		required: make([]string, 0),
+		cache:    make(map[string]string, 0),
+	}
+	if goModFile.Module != nil {
+		mr.required = append(mr.required, goModFile.Module.Mod.Path)
+	}
+	for _, r := range goModFile.Require {
+		mr.required = append(mr.required, r.Mod.Path)
+	}
+	return mr
+}
+
+// ModuleOf returns path of the module which provides the package
+func (mr *ModuleResolver) ModuleOf(importPath string) (string, error) {
+	if mod, ok := mr.cache[importPath]; ok {
+		return mod, nil
+	}
+
+	mod := MatchModule(mr.required, importPath)
+	if mod == "" {
+		mod = MatchModule(mr.listModules(), importPath)
+	}
+	if mod == "" {
+		var err error
+		mod, err = repoRootForImportPath(importPath)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	mr.cache[importPath] = mod
+	return mod, nil
+}
+
+// listModules returns all modules in the build list (`go list -m all`),
+// or nothing if they cannot be listed
+func (mr *ModuleResolver) listModules() []string {
+	if mr.listedLoaded {
+		return mr.listed
+	}
+	mr.listedLoaded = true
+
+	cmd := exec.Command("go", "list", "-m", "all")
+	cmd.Dir = mr.dir
+	var stdout bytes.Buffer
+	cmd.Env = append(os.Environ(), "GO111MODULE=on")
+	cmd.Stdout = &stdout
+
+	err := cmd.Run()
+	if err != nil {
+		return nil
+	}
+
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 {
+			mr.listed = append(mr.listed, fields[0])
+		}
+	}
+	return mr.listed
+}
+
+// MatchModule returns the longest of module paths which contains the package
+func MatchModule(modulePaths []string, importPath string) string {
+	found := ""
+	for _, mp := range modulePaths {
+		if importPath != mp && !strings.HasPrefix(importPath, mp+"/") {
+			continue
+		}
+		if len(mp) > len(found) {
+			found = mp
+		}
+	}
+	return found
+}
@@ -0,0 +1,48 @@
+package gomod
+
+import (
+	"testing"
+
+	"golang.org/x/mod/modfile"
+)
+
+func TestModuleResolverModuleOf(t *testing.T) {
+	goModFile, err := modfile.Parse("/nonexistent/go.mod", []byte(`module github.com/hashicorp/terraform
+
+require (
+	github.com/hashicorp/hcl v1.0.0
+	github.com/hashicorp/hcl/v2 v2.0.0
+	github.com/hashicorp/hcl2 v0.0.0-20191002203319-fb75b3253c80
+)
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mr := NewModuleResolver(goModFile)
+	// Avoid running go list in tests
+	mr.listed = []string{"github.com/zclconf/go-cty"}
+	mr.listedLoaded = true
+
+	testCases := []struct {
+		importPath     string
+		expectedModule string
+	}{
+		{"github.com/hashicorp/hcl", "github.com/hashicorp/hcl"},
+		{"github.com/hashicorp/hcl/hcl/ast", "github.com/hashicorp/hcl"},
+		{"github.com/hashicorp/hcl/v2/hclsyntax", "github.com/hashicorp/hcl/v2"},
+		{"github.com/hashicorp/hcl2/gohcl", "github.com/hashicorp/hcl2"},
+		{"github.com/hashicorp/terraform/config", "github.com/hashicorp/terraform"},
+		{"github.com/zclconf/go-cty/cty", "github.com/zclconf/go-cty"},
+	}
+
+	for _, tc := range testCases {
+		mod, err := mr.ModuleOf(tc.importPath)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.importPath, err)
+		}
+		if mod != tc.expectedModule {
+			t.Fatalf("%s: expected %q, given: %q", tc.importPath, tc.expectedModule, mod)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package gomod
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// SumEntry is a single line of go.sum
+type SumEntry struct {
+	Module module.Version
+	// GoMod is true for hashes of the go.mod file only (version/go.mod)
+	GoMod bool
+	Hash  string
+}
+
+// ParseSumFile parses go.sum file
+func ParseSumFile(path string) ([]*SumEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseSum(f, path)
+}
+
+func parseSum(r io.Reader, name string) ([]*SumEntry, error) {
+	entries := make([]*SumEntry, 0)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: Malformed go.sum line", name, line)
+		}
+
+		version := fields[1]
+		goMod := strings.HasSuffix(version, "/go.mod")
+		entries = append(entries, &SumEntry{
+			Module: module.Version{Path: fields[0], Version: strings.TrimSuffix(version, "/go.mod")},
+			GoMod:  goMod,
+			Hash:   fields[2],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
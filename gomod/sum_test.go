@@ -0,0 +1,70 @@
+package gomod
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/mod/module"
+)
+
+func TestParseSum(t *testing.T) {
+	input := `github.com/BurntSushi/toml v0.3.1 h1:WXkYYl6Yr3qBf1K79EBnL4mak0OimBfB0XUf9Vl28OQ=
+github.com/BurntSushi/toml v0.3.1/go.mod h1:xHWCNGjB5oqiDr8zfno3MHue2Ht5sIBksp03qcyfWMU=
+
+golang.org/x/xerrors v0.0.0-20191011141410-1b5146add898/go.mod h1:I/5z698sn9Ujc0iU2kDiOQkUE4RL9k4C8FG33iVfXHY=
+`
+	entries, err := parseSum(strings.NewReader(input), "go.sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*SumEntry{
+		{
+			Module: module.Version{Path: "github.com/BurntSushi/toml", Version: "v0.3.1"},
+			Hash:   "h1:WXkYYl6Yr3qBf1K79EBnL4mak0OimBfB0XUf9Vl28OQ=",
+		},
+		{
+			Module: module.Version{Path: "github.com/BurntSushi/toml", Version: "v0.3.1"},
+			GoMod:  true,
+			Hash:   "h1:xHWCNGjB5oqiDr8zfno3MHue2Ht5sIBksp03qcyfWMU=",
+		},
+		{
+			Module: module.Version{Path: "golang.org/x/xerrors", Version: "v0.0.0-20191011141410-1b5146add898"},
+			GoMod:  true,
+			Hash:   "h1:I/5z698sn9Ujc0iU2kDiOQkUE4RL9k4C8FG33iVfXHY=",
+		},
+	}
+	if !reflect.DeepEqual(expected, entries) {
+		t.Fatalf("Expected: %#v\ngiven: %#v", expected, entries)
+	}
+}
+
+func TestParseSum_invalid(t *testing.T) {
+	_, err := parseSum(strings.NewReader("github.com/BurntSushi/toml v0.3.1\n"), "go.sum")
+	if err == nil {
+		t.Fatal("Expected error for malformed line")
+	}
+}
+
+func TestModuleCache(t *testing.T) {
+	mc := &ModuleCache{Dir: "/go/pkg/mod"}
+	mv := module.Version{Path: "github.com/BurntSushi/toml", Version: "v0.3.1"}
+
+	dir, err := mc.ModuleDir(mv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := filepath.FromSlash("/go/pkg/mod/github.com/!burnt!sushi/toml@v0.3.1"); dir != expected {
+		t.Fatalf("Expected %q, given: %q", expected, dir)
+	}
+
+	modFile, err := mc.DownloadFile(mv, ".mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := filepath.FromSlash("/go/pkg/mod/cache/download/github.com/!burnt!sushi/toml/@v/v0.3.1.mod"); modFile != expected {
+		t.Fatalf("Expected %q, given: %q", expected, modFile)
+	}
+}
@@ -3,9 +3,9 @@ package govendor
 import (
 	"io"
 	"os"
-	"strings"
 
 	"github.com/kardianos/govendor/vendorfile"
+	"github.com/radeksimko/go-mod-diff/gomod"
 )
 
 func ParseFile(path string) (*vendorfile.File, error) {
@@ -23,24 +23,15 @@ func ParseFile(path string) (*vendorfile.File, error) {
 	return vf, nil
 }
 
-func FindPackages(packages []*vendorfile.Package, importPath string) []*vendorfile.Package {
+// PackagesForModule returns all vendored packages which belong to the module,
+// packages of modules nested in it (among modulePaths) are left out
+func PackagesForModule(packages []*vendorfile.Package, modulePaths []string, modulePath string) []*vendorfile.Package {
+	modulePaths = append([]string{modulePath}, modulePaths...)
 	var pkgs []*vendorfile.Package
 	for _, p := range packages {
-		if strings.HasPrefix(p.Path, importPath) {
-			p.Path = importPath
-			if !packageExists(pkgs, p) {
-				pkgs = append(pkgs, p)
-			}
+		if gomod.MatchModule(modulePaths, p.Path) == modulePath {
+			pkgs = append(pkgs, p)
 		}
 	}
 	return pkgs
 }
-
-func packageExists(packages []*vendorfile.Package, pkg *vendorfile.Package) bool {
-	for _, p := range packages {
-		if p.Revision == pkg.Revision {
-			return true
-		}
-	}
-	return false
-}
@@ -1,9 +1,47 @@
 package govendor
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/kardianos/govendor/vendorfile"
 )
 
 func TestGetRevisionOfPackage(t *testing.T) {
 	t.Skip("todo")
 }
+
+func TestPackagesForModule(t *testing.T) {
+	packages := []*vendorfile.Package{
+		{Path: "github.com/hashicorp/hcl", Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241"},
+		{Path: "github.com/hashicorp/hcl/hcl/ast", Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241"},
+		{Path: "github.com/hashicorp/hcl/sdk/x", Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938"},
+		{Path: "github.com/hashicorp/hcl/v2/gohcl", Revision: "f21a4dfb5e38f5895301dc265a8def02365cc3d0"},
+		{Path: "github.com/hashicorp/hcl2/gohcl", Revision: "6743a2254ba3d642b7d3a0be506259a0842819df"},
+		{Path: "golang.org/x/net/context", Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f"},
+	}
+	modulePaths := []string{
+		"github.com/hashicorp/hcl",
+		"github.com/hashicorp/hcl/sdk",
+		"github.com/hashicorp/hcl/v2",
+		"golang.org/x/net",
+	}
+
+	testCases := []struct {
+		modulePath   string
+		expectedPkgs []*vendorfile.Package
+	}{
+		{"github.com/hashicorp/hcl", packages[0:2]},
+		{"github.com/hashicorp/hcl/sdk", packages[2:3]},
+		{"github.com/hashicorp/hcl/v2", packages[3:4]},
+		// Module paths not required in go.mod
+		{"github.com/hashicorp/hcl2", packages[4:5]},
+	}
+
+	for _, tc := range testCases {
+		pkgs := PackagesForModule(packages, modulePaths, tc.modulePath)
+		if !reflect.DeepEqual(tc.expectedPkgs, pkgs) {
+			t.Fatalf("%s: Expected %#v, given: %#v", tc.modulePath, tc.expectedPkgs, pkgs)
+		}
+	}
+}
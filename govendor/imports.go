@@ -0,0 +1,174 @@
+package govendor
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportGraph is the graph of imports between packages of a project
+// and packages in its vendor tree, as parsed from the source code
+type ImportGraph struct {
+	// roots are packages of the project itself
+	roots   []string
+	imports map[string][]string
+}
+
+// LoadImportGraph parses imports of all packages in projectDir (imported
+// as rootPath) and its vendor directory. Tests are only included for
+// the project's packages, as vendored packages are usually stripped of them.
+func LoadImportGraph(projectDir, rootPath string) (*ImportGraph, error) {
+	g := &ImportGraph{
+		roots:   make([]string, 0),
+		imports: make(map[string][]string, 0),
+	}
+	vendorDir := filepath.Join(projectDir, "vendor")
+
+	projectImports := make(map[string][]string, 0)
+	err := walkPackages(projectDir, vendorDir, true, func(rel string, imports []string) {
+		projectImports[path.Join(rootPath, rel)] = imports
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vendored := make(map[string][]string, 0)
+	err = walkPackages(vendorDir, "", false, func(rel string, imports []string) {
+		vendored[rel] = imports
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	// Only keep imports of packages within the project or vendor tree
+	for _, imports := range []map[string][]string{projectImports, vendored} {
+		for pkg, imps := range imports {
+			for _, imp := range imps {
+				_, inProject := projectImports[imp]
+				_, inVendor := vendored[imp]
+				if inProject || inVendor {
+					g.imports[pkg] = append(g.imports[pkg], imp)
+				}
+			}
+		}
+	}
+	for pkg := range projectImports {
+		g.roots = append(g.roots, pkg)
+	}
+	sort.Strings(g.roots)
+
+	return g, nil
+}
+
+// walkPackages calls fn with every directory containing Go files under root
+// (relative to it, slash-separated) along with their imports
+func walkPackages(root, skipDir string, withTests bool, fn func(string, []string)) error {
+	return filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		if p == skipDir || (p != root && (name == "testdata" ||
+			strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_"))) {
+			return filepath.SkipDir
+		}
+
+		imports, err := parseImports(p, withTests)
+		if err != nil {
+			return err
+		}
+		if imports == nil {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		fn(filepath.ToSlash(rel), imports)
+		return nil
+	})
+}
+
+// parseImports returns imports of Go files in dir or nil if there are none
+func parseImports(dir string, withTests bool) ([]string, error) {
+	fset := token.NewFileSet()
+	pkgs, _ := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return withTests || !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ImportsOnly)
+	// Imports of files which parsed fine are still useful
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+
+	seen := map[string]bool{}
+	// Non-nil even without imports, nil means there are no Go files
+	imports := []string{}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, is := range f.Imports {
+				imp, err := strconv.Unquote(is.Path.Value)
+				if err != nil || seen[imp] {
+					continue
+				}
+				seen[imp] = true
+				imports = append(imports, imp)
+			}
+		}
+	}
+	sort.Strings(imports)
+	return imports, nil
+}
+
+// Why returns the shortest import chain from a package of the project
+// to any of the given packages or nil if none of them is imported
+func (g *ImportGraph) Why(pkgPaths []string) []string {
+	targets := make(map[string]bool, len(pkgPaths))
+	for _, p := range pkgPaths {
+		targets[p] = true
+	}
+
+	parents := make(map[string]string, 0)
+	visited := make(map[string]bool, 0)
+	queue := make([]string, 0, len(g.roots))
+	for _, root := range g.roots {
+		visited[root] = true
+		queue = append(queue, root)
+	}
+
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+
+		if targets[pkg] {
+			chain := []string{pkg}
+			for {
+				parent, ok := parents[pkg]
+				if !ok {
+					return chain
+				}
+				chain = append([]string{parent}, chain...)
+				pkg = parent
+			}
+		}
+
+		for _, imp := range g.imports[pkg] {
+			if visited[imp] {
+				continue
+			}
+			visited[imp] = true
+			parents[imp] = pkg
+			queue = append(queue, imp)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package govendor
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/internal/testutil"
+)
+
+func TestImportGraph(t *testing.T) {
+	dir := testutil.TempTree(t, map[string]string{
+		"main.go":                                             "package main\nimport _ \"example.com/foo/internal\"\n",
+		"internal/internal.go":                                "package internal\nimport \"github.com/hashicorp/hcl\"\nvar _ = hcl.Parse\n",
+		"internal/internal_test.go":                           "package internal\nimport _ \"github.com/stretchr/testify/assert\"\n",
+		"vendor/github.com/hashicorp/hcl/hcl.go":              "package hcl\nimport (\n\"fmt\"\n_ \"github.com/hashicorp/hcl/hcl/ast\"\n)\n",
+		"vendor/github.com/hashicorp/hcl/hcl/ast/ast.go":      "package ast\n",
+		"vendor/github.com/stretchr/testify/assert/a.go":      "package assert\n",
+		"vendor/github.com/stretchr/testify/assert/a_test.go": "package assert\nimport _ \"github.com/davecgh/go-spew/spew\"\n",
+		"vendor/github.com/davecgh/go-spew/spew/spew.go":      "package spew\n",
+		"vendor/github.com/hashicorp/unused/unused.go":        "package unused\n",
+		"testdata/broken.go":                                  "package broken\nimport _ \"github.com/hashicorp/unused\"\n",
+	})
+	defer os.RemoveAll(dir)
+
+	g, err := LoadImportGraph(dir, "example.com/foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		pkgs          []string
+		expectedChain []string
+	}{
+		{
+			[]string{"github.com/hashicorp/hcl/hcl/ast"},
+			[]string{"example.com/foo/internal", "github.com/hashicorp/hcl", "github.com/hashicorp/hcl/hcl/ast"},
+		},
+		{
+			[]string{"github.com/stretchr/testify/assert"},
+			[]string{"example.com/foo/internal", "github.com/stretchr/testify/assert"},
+		},
+		// Tests of vendored packages are not scanned
+		{[]string{"github.com/davecgh/go-spew/spew"}, nil},
+		// testdata is not scanned
+		{[]string{"github.com/hashicorp/unused"}, nil},
+	}
+	for _, tc := range testCases {
+		chain := g.Why(tc.pkgs)
+		if !reflect.DeepEqual(tc.expectedChain, chain) {
+			t.Fatalf("%q: expected %q, given: %q", tc.pkgs, tc.expectedChain, chain)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/colorstring"
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/fix"
+	"github.com/radeksimko/go-mod-diff/github"
+	"github.com/radeksimko/go-mod-diff/gomod"
+	"github.com/radeksimko/go-mod-diff/report"
+)
+
+var errInteractiveQuit = errors.New("Aborted, go.mod was not changed")
+
+// interactive walks through differences one by one
+// and asks how each of them should be resolved
+type interactive struct {
+	in  *bufio.Reader
+	out io.Writer
+	c   *colorstring.Colorize
+	tw  *report.TextWriter
+	gh  *github.GitHub
+}
+
+func newInteractive(in io.Reader, out io.Writer, color bool, gh *github.GitHub) *interactive {
+	return &interactive{
+		in:  bufio.NewReader(in),
+		out: out,
+		c:   report.NewColorize(color),
+		tw:  report.NewTextWriter(out, color),
+		gh:  gh,
+	}
+}
+
+func (i *interactive) printf(format string, a ...interface{}) {
+	fmt.Fprintf(i.out, i.c.Color(format), a...)
+}
+
+// prompt prints the question and returns trimmed answer or def if the answer is empty
+func (i *interactive) prompt(question, def string) (string, error) {
+	if def != "" {
+		i.printf("[bold]%s[reset] [%s]: ", question, def)
+	} else {
+		i.printf("[bold]%s[reset]: ", question)
+	}
+
+	line, err := i.in.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return "", errInteractiveQuit
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	answer := strings.TrimSpace(line)
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// Plan asks about every different or not found entry
+// and returns plan of all chosen changes
+func (i *interactive) Plan(r *report.Report) (*fix.Plan, error) {
+	plan := &fix.Plan{
+		Changes: make([]*fix.Change, 0),
+		Skipped: make([]*fix.Skipped, 0),
+	}
+
+	entries := append(append([]*diff.DiffEntry{}, r.Diff.Different...), r.Diff.NotFound...)
+	for n, entry := range entries {
+		i.printf("\n[bold][cyan]Difference %d of %d[reset]\n", n+1, len(entries))
+		i.tw.WriteDiffEntry(entry, r.Why[entry.ModulePath])
+		i.tw.WriteEntryWarnings(entry)
+		i.writeComparisons(entry)
+
+		change, err := i.ask(entry)
+		if err != nil {
+			return nil, err
+		}
+		if change == nil {
+			plan.Skipped = append(plan.Skipped, &fix.Skipped{
+				ModulePath: entry.ModulePath,
+				Reason:     "skipped interactively",
+			})
+			continue
+		}
+		if change.To != change.From {
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	return plan, nil
+}
+
+// ask returns the chosen change, nil if the entry was skipped
+// or a change keeping the go.mod version
+func (i *interactive) ask(de *diff.DiffEntry) (*fix.Change, error) {
+	options := []string{"[k]eep go.mod version"}
+	if len(de.GoVendorVersions) > 0 {
+		options = append(options, "[p]in to govendor revision")
+	}
+	options = append(options, "pin to [t]ag", "[s]kip", "[q]uit")
+
+	for {
+		answer, err := i.prompt(strings.Join(options, ", "), "s")
+		if err != nil {
+			return nil, err
+		}
+
+		var change *fix.Change
+		switch strings.ToLower(answer) {
+		case "k", "keep":
+			return &fix.Change{
+				ModulePath: de.ModulePath,
+				From:       de.GoModVersion.Version,
+				To:         de.GoModVersion.Version,
+			}, nil
+		case "p", "pin":
+			if len(de.GoVendorVersions) == 0 {
+				i.printf("[red]Module was not found in govendor[reset]\n")
+				continue
+			}
+			v, err := i.chooseRevision(de)
+			if err != nil {
+				return nil, err
+			}
+			change, err = fix.PinToRevision(de, v)
+			if err != nil {
+				i.printf("[red]%s[reset]\n", err)
+				continue
+			}
+		case "t", "tag":
+			var def string
+			if de.TagSuggestion != nil {
+				if s := de.TagSuggestion.Suggested(); s != nil {
+					def = s.Tag
+				}
+			}
+			tag, err := i.prompt("Tag", def)
+			if err != nil {
+				return nil, err
+			}
+			change, err = fix.PinToTag(de, tag)
+			if err != nil {
+				i.printf("[red]%s[reset]\n", err)
+				continue
+			}
+		case "s", "skip":
+			return nil, nil
+		case "q", "quit":
+			return nil, errInteractiveQuit
+		default:
+			i.printf("[red]Unknown answer %q[reset]\n", answer)
+			continue
+		}
+
+		i.printf("Will pin [bold]%s[reset] to [bold][cyan]%s[reset]\n", de.ModulePath, change.To)
+		return change, nil
+	}
+}
+
+func (i *interactive) chooseRevision(de *diff.DiffEntry) (*diff.Version, error) {
+	versions := de.GoVendorVersions
+	if len(versions) == 1 {
+		return versions[0], nil
+	}
+
+	def := 1
+	for n, v := range versions {
+		if de.Reconciliation != nil && de.Reconciliation.Target == v {
+			def = n + 1
+		}
+		i.printf("   %d) %s\n", n+1, v.String())
+	}
+
+	for {
+		answer, err := i.prompt(fmt.Sprintf("Revision (1-%d)", len(versions)), strconv.Itoa(def))
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err != nil || n < 1 || n > len(versions) {
+			i.printf("[red]Expected number between 1 and %d[reset]\n", len(versions))
+			continue
+		}
+		return versions[n-1], nil
+	}
+}
+
+// writeComparisons shows how far is the go.mod version
+// from each govendor revision, where GitHub can tell
+func (i *interactive) writeComparisons(de *diff.DiffEntry) {
+	resolved := de.ResolvedGoModVersion()
+	repo := de.VersionRepository(resolved)
+	if repo == nil || i.gh == nil {
+		return
+	}
+	ref, err := gomod.ParseRefFromVersion(resolved.Version)
+	if err != nil {
+		return
+	}
+
+	for _, v := range de.GoVendorVersions {
+		// Comparison across forks would need owner prefixes
+		if v.Origin != resolved.Origin {
+			continue
+		}
+		cmp, err := i.gh.CompareCommits(repo, v.Revision, ref.String())
+		if err != nil {
+			i.printf(" - [yellow]Failed to compare %s with go.mod version: %s[reset]\n",
+				report.ShortRevision(v.Revision), err)
+			continue
+		}
+		i.printf(" - go.mod version vs %s: %s (%d ahead, %d behind)\n",
+			report.ShortRevision(v.Revision), cmp.Status, cmp.AheadBy, cmp.BehindBy)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/fix"
+	"github.com/radeksimko/go-mod-diff/report"
+	"golang.org/x/mod/modfile"
+)
+
+func testEntry() *diff.DiffEntry {
+	return &diff.DiffEntry{
+		ModulePath:   "github.com/hashicorp/hcl",
+		GoModVersion: &diff.Version{Version: "v0.0.0-20170504190234-a4b07c25de5f"},
+		GoVendorVersions: []*diff.Version{
+			{
+				Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241",
+				Time:     "2017-08-08T11:21:55Z",
+			},
+		},
+	}
+}
+
+func TestInteractiveAsk(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		entry       func() *diff.DiffEntry
+		expectedTo  string
+		expectedErr error
+		expectedOut string
+	}{
+		{"keep", "k\n", testEntry, "v0.0.0-20170504190234-a4b07c25de5f", nil, ""},
+		{"keep (long)", "KEEP\n", testEntry, "v0.0.0-20170504190234-a4b07c25de5f", nil, ""},
+		{"pin", "p\n", testEntry, "v0.0.0-20170808112155-8cb6e5b95923", nil, "Will pin"},
+		{"tag", "t\nv1.2.0\n", testEntry, "v1.2.0", nil, "Will pin"},
+		{
+			"suggested tag",
+			"t\n\n",
+			func() *diff.DiffEntry {
+				de := testEntry()
+				de.TagSuggestion = &diff.TagSuggestion{After: &diff.TagDistance{Tag: "v1.1.0", Commits: 3}}
+				return de
+			},
+			"v1.1.0", nil, "Tag [v1.1.0]",
+		},
+		{"invalid tag", "t\nlatest\nk\n", testEntry, "v0.0.0-20170504190234-a4b07c25de5f", nil, "not a canonical semver tag"},
+		{"unknown answer", "x\nk\n", testEntry, "v0.0.0-20170504190234-a4b07c25de5f", nil, `Unknown answer "x"`},
+		{
+			"pin without govendor",
+			"p\nk\n",
+			func() *diff.DiffEntry {
+				de := testEntry()
+				de.GoVendorVersions = nil
+				return de
+			},
+			"v0.0.0-20170504190234-a4b07c25de5f", nil, "Module was not found in govendor",
+		},
+		{"skip", "s\n", testEntry, "", nil, ""},
+		{"skip by default", "\n", testEntry, "", nil, ""},
+		{"quit", "q\n", testEntry, "", errInteractiveQuit, ""},
+		{"EOF", "", testEntry, "", errInteractiveQuit, ""},
+		{"EOF after unknown answer", "x\n", testEntry, "", errInteractiveQuit, ""},
+		{"EOF in tag prompt", "t\n", testEntry, "", errInteractiveQuit, ""},
+	}
+
+	for _, tc := range testCases {
+		var out bytes.Buffer
+		i := newInteractive(strings.NewReader(tc.input), &out, false, nil)
+		change, err := i.ask(tc.entry())
+		if err != tc.expectedErr {
+			t.Fatalf("%s: Expected error %v, given: %v", tc.name, tc.expectedErr, err)
+		}
+		to := ""
+		if change != nil {
+			to = change.To
+		}
+		if to != tc.expectedTo {
+			t.Fatalf("%s: Expected change to %q, given: %#v", tc.name, tc.expectedTo, change)
+		}
+		if !strings.Contains(out.String(), tc.expectedOut) {
+			t.Fatalf("%s: Expected output to contain %q, given:\n%s", tc.name, tc.expectedOut, out.String())
+		}
+	}
+}
+
+func TestInteractiveChooseRevision(t *testing.T) {
+	versions := []*diff.Version{
+		{Revision: "8cb6e5b959231cc1119e43259c4a608f9c51a241", Time: "2017-08-08T11:21:55Z"},
+		{Revision: "a4b07c25de5ff55ad3b8936cea69a79a3d95a855", Time: "2017-05-04T19:02:34Z"},
+		{Revision: "23480c0665776210b5fbbac6eaaee40e3e6a96b7", Time: "2018-01-01T00:00:00Z"},
+	}
+
+	testCases := []struct {
+		name          string
+		input         string
+		target        *diff.Version
+		expected      *diff.Version
+		expectedErr   error
+		expectedQuery string
+	}{
+		{"first by default", "\n", nil, versions[0], nil, "Revision (1-3) [1]"},
+		{"reconciliation target by default", "\n", versions[2], versions[2], nil, "Revision (1-3) [3]"},
+		{"chosen", "2\n", versions[2], versions[1], nil, ""},
+		{"out of range", "4\n0\nabc\n2\n", nil, versions[1], nil, "Expected number between 1 and 3"},
+		{"EOF", "", nil, nil, errInteractiveQuit, ""},
+	}
+
+	for _, tc := range testCases {
+		de := testEntry()
+		de.GoVendorVersions = versions
+		if tc.target != nil {
+			de.Reconciliation = &diff.Reconciliation{Target: tc.target}
+		}
+
+		var out bytes.Buffer
+		i := newInteractive(strings.NewReader(tc.input), &out, false, nil)
+		v, err := i.chooseRevision(de)
+		if err != tc.expectedErr {
+			t.Fatalf("%s: Expected error %v, given: %v", tc.name, tc.expectedErr, err)
+		}
+		if v != tc.expected {
+			t.Fatalf("%s: Expected %v, given: %v", tc.name, tc.expected, v)
+		}
+		if !strings.Contains(out.String(), tc.expectedQuery) {
+			t.Fatalf("%s: Expected output to contain %q, given:\n%s", tc.name, tc.expectedQuery, out.String())
+		}
+	}
+}
+
+func TestInteractivePlan(t *testing.T) {
+	goModFile, err := modfile.Parse("go.mod", []byte(`module example.com/foo
+
+require (
+	github.com/hashicorp/hcl v0.0.0-20170504190234-a4b07c25de5f
+	github.com/hashicorp/go-version v1.0.0
+	golang.org/x/net v0.0.0-20180101000000-aaaaaaaaaaaa
+)
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hcl := testEntry()
+	goVersion := &diff.DiffEntry{
+		ModulePath:   "github.com/hashicorp/go-version",
+		GoModVersion: &diff.Version{Version: "v1.0.0"},
+	}
+	net := &diff.DiffEntry{
+		ModulePath:   "golang.org/x/net",
+		GoModVersion: &diff.Version{Version: "v0.0.0-20180101000000-aaaaaaaaaaaa"},
+	}
+	d := &diff.Diff{
+		Different: []*diff.DiffEntry{hcl},
+		NotFound:  []*diff.DiffEntry{goVersion, net},
+	}
+	r := report.New(d, goModFile)
+
+	var out bytes.Buffer
+	i := newInteractive(strings.NewReader("p\nk\ns\n"), &out, false, nil)
+	plan, err := i.Plan(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Kept versions are not changes
+	expectedChanges := []string{"github.com/hashicorp/hcl v0.0.0-20170504190234-a4b07c25de5f => v0.0.0-20170808112155-8cb6e5b95923"}
+	changes := make([]string, 0)
+	for _, c := range plan.Changes {
+		changes = append(changes, c.String())
+	}
+	if !reflect.DeepEqual(expectedChanges, changes) {
+		t.Fatalf("Expected changes: %q\ngiven: %q", expectedChanges, changes)
+	}
+	expectedSkipped := []*fix.Skipped{{ModulePath: "golang.org/x/net", Reason: "skipped interactively"}}
+	if !reflect.DeepEqual(expectedSkipped, plan.Skipped) {
+		t.Fatalf("Expected skipped: %#v\ngiven: %#v", expectedSkipped, plan.Skipped)
+	}
+	if !strings.Contains(out.String(), "Difference 3 of 3") {
+		t.Fatalf("Expected all differences to be shown, given:\n%s", out.String())
+	}
+
+	// Quitting discards all decisions
+	i = newInteractive(strings.NewReader("p\nq\n"), &out, false, nil)
+	_, err = i.Plan(r)
+	if err != errInteractiveQuit {
+		t.Fatalf("Expected quit error, given: %v", err)
+	}
+}
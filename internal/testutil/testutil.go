@@ -0,0 +1,30 @@
+// Package testutil contains helpers shared by tests of several packages
+package testutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TempTree creates a temporary directory with the given files
+// (slash-separated paths mapped to their content) and returns its path
+func TempTree(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "go-mod-diff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
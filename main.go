@@ -1,145 +1,167 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"log"
+	"io"
 	"os"
-	"path/filepath"
+	"strings"
 
-	"github.com/mitchellh/colorstring"
-	"github.com/radeksimko/go-mod-diff/diff"
 	"github.com/radeksimko/go-mod-diff/github"
 	"github.com/radeksimko/go-mod-diff/gomod"
-	"github.com/radeksimko/go-mod-diff/govendor"
+	"github.com/radeksimko/go-mod-diff/report"
+	"golang.org/x/mod/modfile"
 )
 
+// version is set during release via -ldflags "-X main.version=..."
+var version = "dev"
+
+const (
+	// exitOK means no differences in buckets chosen via -fail-on were found
+	exitOK = 0
+	// exitDifferent means differences in buckets chosen via -fail-on were found
+	exitDifferent = 1
+	// exitError means the command itself failed
+	exitError = 2
+)
+
+type command struct {
+	Name     string
+	Synopsis string
+	Run      func(args []string) int
+}
+
+var commands = []*command{
+	{"compare", "Compare go.mod with dependencies pinned by another dependency manager", compareCommand},
+	{"why", "Explain why modules are needed", whyCommand},
+	{"resolve", "Resolve go.mod version of modules to revisions on GitHub", resolveCommand},
+	{"fix", "Pin go.mod requirements to revisions pinned by another dependency manager", fixCommand},
+	{"graph", "Show which dependent modules require each version of modules", graphCommand},
+	{"verify", "Verify vendored packages against contents of go.mod modules", verifyCommand},
+}
+
 func main() {
-	// Setup GitHub connection
-	gh := github.NewGitHub()
-	if os.Getenv("GITHUB_TOKEN") != "" {
-		gh = github.NewGitHubWithToken(os.Getenv("GITHUB_TOKEN"))
-	}
+	os.Exit(realMain(os.Args[1:]))
+}
 
-	// Parse go modules file
-	cwd, err := os.Getwd()
-	if err != nil {
-		log.Fatal(err)
+func realMain(args []string) int {
+	if len(args) == 0 {
+		printUsage(os.Stderr)
+		return exitError
 	}
-	goModFile, err := gomod.ParseFile(filepath.Join(cwd, "go.mod"))
 
-	// Parse govendor file
-	govendorFile, err := govendor.ParseFile(os.Args[1])
-	if err != nil {
-		log.Fatal(err)
+	switch args[0] {
+	case "-h", "-help", "--help", "help":
+		printUsage(os.Stdout)
+		return exitOK
+	case "-v", "-version", "--version", "version":
+		fmt.Printf("go-mod-diff %s\n", version)
+		return exitOK
 	}
 
-	// Compare both and print out differences
-	d, err := diff.CompareGoModWithGovendor(goModFile, govendorFile, gh)
-	if err != nil {
-		log.Fatal(err)
+	for _, cmd := range commands {
+		if cmd.Name == args[0] {
+			return cmd.Run(args[1:])
+		}
 	}
 
-	printDifference(d, gomod.GetVersionForModule(goModFile))
-
-	total := len(goModFile.Require) - len(d.Matched)
+	// Keep supporting `go-mod-diff [options] vendor.json` from before subcommands existed
+	if strings.HasPrefix(args[0], "-") || fileExists(args[0]) {
+		return compareCommand(args)
+	}
 
-	colorstring.Printf("\n\nMatched package revisions: [bold][green]%d[reset] of %d.\n"+
-		"[bold]%d[reset] to check ([bold][red]%d[reset] not found and [bold][yellow]%d[reset] different revs).\n",
-		len(d.Matched), len(goModFile.Require), total, len(d.NotFound), len(d.Different))
+	fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", args[0])
+	printUsage(os.Stderr)
+	return exitError
 }
 
-func printDifference(d *diff.Diff, vlF gomod.VersionLookupFunc) {
-	for _, entry := range d.Errored {
-		printDiffEntry(entry, vlF)
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: go-mod-diff <command> [options] [args]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.Name, cmd.Synopsis)
 	}
+	fmt.Fprintf(w, "\nRun go-mod-diff <command> -help for options of each command.\n")
+}
 
-	for _, entry := range d.NotFound {
-		printDiffEntry(entry, vlF)
+// newFlagSet returns flag set which prints usage of the command on -help
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: go-mod-diff %s %s\n\nOptions:\n", name, usage)
+		fs.PrintDefaults()
 	}
+	return fs
+}
 
-	for _, entry := range d.Different {
-		printDiffEntry(entry, vlF)
+// parseFlags returns exit code and false if the command should not continue
+func parseFlags(fs *flag.FlagSet, args []string, cf *commonFlags) (int, bool) {
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return exitOK, false
+	}
+	if err != nil {
+		return exitError, false
 	}
 
-	for _, entry := range d.Matched {
-		colorstring.Printf("\n[bold]%s[reset] [bold][green]✓[reset]", entry.ModulePath)
+	if cf.noColor {
+		cf.colorMode = colorNever
 	}
+	err = validateColorMode(cf.colorMode)
+	if err != nil {
+		return cf.fail(err), false
+	}
+
+	return exitOK, true
 }
 
-func printDiffEntry(de *diff.DiffEntry, vlF gomod.VersionLookupFunc) {
-	colorstring.Printf("\n[bold]%s[reset]\n", de.ModulePath)
+// commonFlags are flags shared by all commands
+type commonFlags struct {
+	modFilePath string
+	githubToken string
+	colorMode   string
+	noColor     bool
+}
 
-	colorstring.Printf(" - go modules: %s\n", de.GoModVersion.String())
+func (cf *commonFlags) addFlags(fs *flag.FlagSet) {
+	fs.StringVar(&cf.modFilePath, "modfile", "go.mod", "Path to go.mod file")
+	fs.StringVar(&cf.githubToken, "github-token", "", "GitHub API token (defaults to $GITHUB_TOKEN)")
+	fs.StringVar(&cf.colorMode, "color", colorAuto,
+		"Colorize output (auto, always or never), auto disables colors when not writing to terminal or NO_COLOR is set")
+	fs.BoolVar(&cf.noColor, "no-color", false, "Disable colorized output (same as -color=never)")
+}
 
-	if de.Error != nil {
-		colorstring.Printf(" - [bold][red]Error:[reset] [red]%s[reset]\n", de.Error.Error())
+func (cf *commonFlags) github() *github.GitHub {
+	token := cf.githubToken
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
 	}
-
-	repo, err := github.ParseRepositoryURL(de.ModulePath)
-	if err == nil {
-		ref, err := gomod.ParseRefFromVersion(de.GoModVersion.Version)
-		if err == nil {
-			fmt.Printf(" - GitHub: %s\n", github.TreeURL(repo, ref.String()))
-		}
+	if token != "" {
+		return github.NewGitHubWithToken(token)
 	}
+	return github.NewGitHub()
+}
 
-	if de.GithubVersion != nil {
-		colorstring.Printf(" - GitHub rev: %s\n", de.GithubVersion.String())
+func (cf *commonFlags) parseModFile() (*modfile.File, error) {
+	f, err := gomod.ParseFile(cf.modFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse go.mod: %s", err)
 	}
+	return f, nil
+}
 
-	fmt.Print(" - govendor: ")
-	if len(de.GoVendorVersions) > 0 {
-		fmt.Printf("[\n")
-		for _, gvv := range de.GoVendorVersions {
-			if gvv.IsEqual(de.GoModVersion) || gvv.IsEqual(de.GithubVersion) {
-				colorstring.Printf("       [green]%s\n", gvv.String())
-			} else {
-				fmt.Printf("       %s\n", gvv.String())
-			}
-		}
-		fmt.Print("   ]\n")
-	} else {
-		colorstring.Print("[red]not found\n")
-	}
+// color returns whether output written to f should be colorized
+func (cf *commonFlags) color(f *os.File) bool {
+	return useColor(cf.colorMode, f)
+}
 
-	printGoModWhy(de.ModulePath, vlF)
+// fail prints the error to stderr, colorized according to the color flags
+func (cf *commonFlags) fail(err error) int {
+	c := report.NewColorize(cf.color(os.Stderr))
+	fmt.Fprintf(os.Stderr, c.Color("[bold][red]Error:[reset] %s\n"), err)
+	return exitError
 }
 
-func printGoModWhy(path string, vlF gomod.VersionLookupFunc) {
-	fmt.Printf(" - go mod why: ")
-	mts, stderr, err := gomod.GoModWhy(path)
-	if err != nil {
-		colorstring.Printf("[bold][red]Failed to check (%s)[reset][red]\n%s", err, stderr)
-		return
-	}
-	if len(mts) > 0 {
-		fmt.Printf("[")
-	} else {
-		colorstring.Printf("[bold][red]Package not needed (try `go mod tidy`)\n")
-	}
-	for _, mt := range mts {
-		for _, t := range mt {
-			versionSuffix := ""
-			githubURL := ""
-
-			version := vlF(t)
-			if version != "" {
-				versionSuffix = " @ " + version
-
-				repo, err := github.ParseRepositoryURL(t)
-				if err == nil {
-					ref, err := gomod.ParseRefFromVersion(version)
-					if err == nil {
-						githubURL = fmt.Sprintf(" (%s)", github.TreeURL(repo, ref.String()))
-					}
-				}
-			}
-
-			fmt.Printf("\n     %s%s%s", t, versionSuffix, githubURL)
-		}
-		fmt.Println("")
-	}
-	if len(mts) > 0 {
-		fmt.Printf("   ]\n")
-	}
+func fileExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && !fi.IsDir()
 }
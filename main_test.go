@@ -0,0 +1,241 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/internal/testutil"
+	"github.com/radeksimko/go-mod-diff/policy"
+	"github.com/radeksimko/go-mod-diff/report"
+)
+
+func TestParseFailOn(t *testing.T) {
+	r := &report.Report{
+		Diff: &diff.Diff{
+			Different: []*diff.DiffEntry{{ModulePath: "github.com/hashicorp/hcl"}},
+		},
+		StaleAcceptances: []*policy.StaleAcceptance{{}, {}},
+	}
+
+	testCases := []struct {
+		value          string
+		expectedCounts []int
+		expectErr      bool
+	}{
+		{"", []int{}, false},
+		{"none", []int{}, false},
+		{"different,notfound,errored", []int{1, 0, 0}, false},
+		{"errored, stale", []int{0, 2}, false},
+		{"different,unknown", nil, true},
+		{"all", nil, true},
+	}
+
+	for _, tc := range testCases {
+		funcs, err := parseFailOn(tc.value)
+		if tc.expectErr {
+			if err == nil {
+				t.Fatalf("%q: Expected error", tc.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: %s", tc.value, err)
+		}
+		if len(funcs) != len(tc.expectedCounts) {
+			t.Fatalf("%q: Expected %d categories, given: %d", tc.value, len(tc.expectedCounts), len(funcs))
+		}
+		for i, f := range funcs {
+			if count := f(r); count != tc.expectedCounts[i] {
+				t.Fatalf("%q: Expected count %d of category %d, given: %d", tc.value, tc.expectedCounts[i], i, count)
+			}
+		}
+	}
+}
+
+func TestValidateColorMode(t *testing.T) {
+	for _, mode := range []string{colorAuto, colorAlways, colorNever} {
+		if err := validateColorMode(mode); err != nil {
+			t.Fatalf("%q: %s", mode, err)
+		}
+	}
+	for _, mode := range []string{"", "yes", "Always"} {
+		if err := validateColorMode(mode); err == nil {
+			t.Fatalf("%q: Expected error", mode)
+		}
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	f, err := ioutil.TempFile("", "go-mod-diff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	defer setenv(t, "NO_COLOR", "")()
+	defer setenv(t, "TERM", "xterm")()
+
+	if !useColor(colorAlways, f) {
+		t.Fatal("Expected colors to be forced")
+	}
+	if useColor(colorNever, os.Stdout) {
+		t.Fatal("Expected colors to be disabled")
+	}
+	if useColor(colorAuto, f) {
+		t.Fatal("Expected no colors in a regular file")
+	}
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	if useColor(colorAuto, devNull) {
+		t.Fatalf("Expected no colors in %s (a character device, but not a terminal)", os.DevNull)
+	}
+
+	restore := setenv(t, "NO_COLOR", "1")
+	if !useColor(colorAlways, f) {
+		t.Fatal("Expected NO_COLOR not to override -color=always")
+	}
+	restore()
+}
+
+// setenv sets the environment variable (unsets it if value is empty)
+// and returns function restoring the previous value
+func setenv(t *testing.T, key, value string) func() {
+	previous, ok := os.LookupEnv(key)
+	set := func(value string, ok bool) {
+		var err error
+		if ok {
+			err = os.Setenv(key, value)
+		} else {
+			err = os.Unsetenv(key)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	set(value, value != "")
+	return func() {
+		set(previous, ok)
+	}
+}
+
+func TestRealMain(t *testing.T) {
+	dir := testutil.TempTree(t, map[string]string{
+		"go.mod": `module example.com/foo
+
+require (
+	example.com/bar v0.0.0-20180413091243-1c05540f6879
+	example.com/baz v0.0.0-20180413091243-3b0461eec859
+)
+`,
+		"matching.json": `{
+	"package": [
+		{"path": "example.com/bar", "revision": "1c05540f6879653db88113bc4a2b70aec4bd491f"},
+		{"path": "example.com/baz", "revision": "3b0461eec859c4b73bb64fdc8285971fd33e3938"}
+	]
+}`,
+		"different.json": `{
+	"package": [
+		{"path": "example.com/bar", "revision": "1c05540f6879653db88113bc4a2b70aec4bd491f"},
+		{"path": "example.com/baz", "revision": "f21a4dfb5e38f5895301dc265a8def02365cc3d0"}
+	]
+}`,
+	})
+	defer os.RemoveAll(dir)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	// Usage, errors and text output of legacy usage (without -o) are not interesting
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = devNull, devNull
+	defer func() {
+		os.Stdout, os.Stderr = stdout, stderr
+	}()
+
+	output := filepath.Join(dir, "output.txt")
+	testCases := []struct {
+		args     []string
+		expected int
+	}{
+		{[]string{}, exitError},
+		{[]string{"unknown"}, exitError},
+		{[]string{"-help"}, exitOK},
+		{[]string{"compare", "-o", output, "matching.json"}, exitOK},
+		{[]string{"compare", "-o", output, "different.json"}, exitDifferent},
+		{[]string{"compare", "-o", output, "-fail-on=none", "different.json"}, exitOK},
+		{[]string{"compare", "-color=sometimes", "matching.json"}, exitError},
+		{[]string{"compare", "-o", output, "missing.json"}, exitError},
+
+		// Legacy usage from before subcommands existed
+		{[]string{"matching.json"}, exitOK},
+		{[]string{"-o", output, "different.json"}, exitDifferent},
+		{[]string{"-o", output, "-modfile", filepath.Join(dir, "go.mod"), "different.json"}, exitDifferent},
+	}
+
+	for _, tc := range testCases {
+		if code := realMain(tc.args); code != tc.expected {
+			t.Fatalf("%q: Expected exit code %d, given: %d", tc.args, tc.expected, code)
+		}
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := testutil.TempTree(t, map[string]string{
+		"proj/go.mod": "module example.com/foo\n",
+		"proj/" + policy.DefaultFilename: `accept "github.com/hashicorp/hcl" {
+  justification = "Upgraded"
+}
+`,
+		"other/go.mod": "module example.com/bar\n",
+		"other/policy.hcl": `accept "golang.org/x/*" {
+  justification = "Tracked separately"
+}
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		path           string
+		modFilePath    string
+		expectedModule string
+	}{
+		{"", filepath.Join(dir, "proj", "go.mod"), "github.com/hashicorp/hcl"},
+		{"", filepath.Join(dir, "other", "go.mod"), ""},
+		{filepath.Join(dir, "other", "policy.hcl"), filepath.Join(dir, "proj", "go.mod"), "golang.org/x/*"},
+	}
+
+	for i, tc := range testCases {
+		pol, err := loadPolicy(tc.path, tc.modFilePath)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if tc.expectedModule == "" {
+			if pol != nil {
+				t.Fatalf("%d: Expected no policy, given: %#v", i, pol)
+			}
+			continue
+		}
+		if pol == nil || len(pol.Accepted) != 1 || pol.Accepted[0].Module != tc.expectedModule {
+			t.Fatalf("%d: Expected policy accepting %s, given: %#v", i, tc.expectedModule, pol)
+		}
+	}
+}
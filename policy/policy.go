@@ -0,0 +1,175 @@
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	"github.com/hashicorp/hcl"
+	"github.com/radeksimko/go-mod-diff/diff"
+)
+
+// DefaultFilename is the policy file looked up next to go.mod
+const DefaultFilename = ".go-mod-diff.hcl"
+
+// Policy lists differences which were made intentionally
+type Policy struct {
+	Accepted []*Acceptance `hcl:"accept"`
+}
+
+// Acceptance accepts go.mod version of modules matching the path or glob
+// (as understood by path.Match), e.g.
+//
+//	accept "github.com/hashicorp/hcl" {
+//	  version       = "v1.0.0"
+//	  justification = "Upgraded to get HCL 1.0 parser fixes"
+//	}
+type Acceptance struct {
+	Module string `hcl:",key" json:"module"`
+	// Version is the accepted go.mod version, any version is accepted if empty
+	Version       string `hcl:"version" json:"version,omitempty"`
+	Justification string `hcl:"justification" json:"justification"`
+}
+
+func (a *Acceptance) String() string {
+	if a.Version == "" {
+		return a.Module
+	}
+	return a.Module + " @ " + a.Version
+}
+
+func (a *Acceptance) matchesModule(modulePath string) bool {
+	matched, _ := path.Match(a.Module, modulePath)
+	return matched
+}
+
+// StaleAcceptance is an acceptance which no longer matches any difference
+type StaleAcceptance struct {
+	Acceptance *Acceptance `json:"acceptance"`
+	Reason     string      `json:"reason"`
+}
+
+func (sa *StaleAcceptance) String() string {
+	return fmt.Sprintf("%s: %s", sa.Acceptance, sa.Reason)
+}
+
+func ParseFile(filename string) (*Policy, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+func Parse(data []byte) (*Policy, error) {
+	p := &Policy{}
+	err := hcl.Unmarshal(data, p)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range p.Accepted {
+		if a.Module == "" {
+			return nil, fmt.Errorf("Expected module path or glob in accept block")
+		}
+		if _, err := path.Match(a.Module, ""); err != nil {
+			return nil, fmt.Errorf("Invalid module glob %q: %s", a.Module, err)
+		}
+		if a.Justification == "" {
+			return nil, fmt.Errorf("Missing justification for accepted difference of %q", a.Module)
+		}
+	}
+
+	return p, nil
+}
+
+// Apply moves accepted entries from Different and NotFound buckets
+// into Accepted and returns acceptances which no longer match
+func (p *Policy) Apply(d *diff.Diff) []*StaleAcceptance {
+	used := make(map[*Acceptance]bool, 0)
+	stale := make([]*StaleAcceptance, 0)
+
+	d.Different = p.accept(d, d.Different, used)
+	d.NotFound = p.accept(d, d.NotFound, used)
+
+	for _, a := range p.Accepted {
+		if used[a] {
+			continue
+		}
+
+		reason := "module not found in go.mod"
+		if entry := staleEntry(a, d); entry != nil {
+			reason = staleReason(a, entry, d)
+		}
+
+		stale = append(stale, &StaleAcceptance{
+			Acceptance: a,
+			Reason:     reason,
+		})
+	}
+
+	return stale
+}
+
+func (p *Policy) accept(d *diff.Diff, entries []*diff.DiffEntry, used map[*Acceptance]bool) []*diff.DiffEntry {
+	remaining := make([]*diff.DiffEntry, 0)
+	for _, entry := range entries {
+		a := p.acceptanceFor(entry)
+		if a == nil {
+			remaining = append(remaining, entry)
+			continue
+		}
+
+		used[a] = true
+		entry.Justification = a.Justification
+		d.Accepted = append(d.Accepted, entry)
+	}
+	return remaining
+}
+
+func (p *Policy) acceptanceFor(entry *diff.DiffEntry) *Acceptance {
+	for _, a := range p.Accepted {
+		if !a.matchesModule(entry.ModulePath) {
+			continue
+		}
+		if a.Version == "" || a.Version == entry.GoModVersion.Version {
+			return a
+		}
+	}
+	return nil
+}
+
+// staleEntry returns the entry of the module the acceptance names exactly,
+// or the first entry matching its glob, nil if there is none
+func staleEntry(a *Acceptance, d *diff.Diff) *diff.DiffEntry {
+	var first *diff.DiffEntry
+	for _, bucket := range [][]*diff.DiffEntry{d.Matched, d.Accepted, d.Different, d.NotFound, d.Errored} {
+		for _, entry := range bucket {
+			if entry.ModulePath == a.Module {
+				return entry
+			}
+			if first == nil && a.matchesModule(entry.ModulePath) {
+				first = entry
+			}
+		}
+	}
+	return first
+}
+
+func staleReason(a *Acceptance, entry *diff.DiffEntry, d *diff.Diff) string {
+	for _, m := range d.Matched {
+		if m == entry {
+			return "go.mod version matches govendor, no difference to accept"
+		}
+	}
+	if a.Version != "" && a.Version != entry.GoModVersion.Version {
+		return fmt.Sprintf("accepted version no longer matches go.mod version %s",
+			entry.GoModVersion.Version)
+	}
+	for _, accepted := range d.Accepted {
+		if accepted == entry {
+			return "module is already accepted by an earlier acceptance"
+		}
+	}
+	return "comparison of the module errored"
+}
@@ -0,0 +1,144 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		src         string
+		expectedErr bool
+		expected    []*Acceptance
+	}{
+		{
+			src: `
+accept "github.com/hashicorp/hcl" {
+  version       = "v1.0.0"
+  justification = "Upgraded to get parser fixes"
+}
+
+accept "golang.org/x/*" {
+  justification = "Tracked separately"
+}
+`,
+			expected: []*Acceptance{
+				{"github.com/hashicorp/hcl", "v1.0.0", "Upgraded to get parser fixes"},
+				{"golang.org/x/*", "", "Tracked separately"},
+			},
+		},
+		{ // missing justification
+			src: `
+accept "github.com/hashicorp/hcl" {
+  version = "v1.0.0"
+}
+`,
+			expectedErr: true,
+		},
+		{ // invalid glob
+			src: `
+accept "github.com/[hashicorp/hcl" {
+  justification = "Oops"
+}
+`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		p, err := Parse([]byte(tc.src))
+		if tc.expectedErr {
+			if err == nil {
+				t.Fatalf("Expected %q to return error, none given.", tc.src)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Parsing %q failed: %s", tc.src, err)
+		}
+
+		if len(p.Accepted) != len(tc.expected) {
+			t.Fatalf("Expected %d acceptances, given: %d", len(tc.expected), len(p.Accepted))
+		}
+		for i, a := range p.Accepted {
+			if *a != *tc.expected[i] {
+				t.Fatalf("Expected %#v, given: %#v", tc.expected[i], a)
+			}
+		}
+	}
+}
+
+func TestPolicyApply(t *testing.T) {
+	hcl := &diff.DiffEntry{
+		ModulePath:   "github.com/hashicorp/hcl",
+		GoModVersion: &diff.Version{Version: "v1.0.0"},
+	}
+	xnet := &diff.DiffEntry{
+		ModulePath:   "golang.org/x/net",
+		GoModVersion: &diff.Version{Version: "v0.0.0-20190620200207-3b0461eec859"},
+	}
+	logrus := &diff.DiffEntry{
+		ModulePath:   "github.com/sirupsen/logrus",
+		GoModVersion: &diff.Version{Version: "v1.4.0"},
+	}
+	gversion := &diff.DiffEntry{
+		ModulePath:   "github.com/hashicorp/go-version",
+		GoModVersion: &diff.Version{Version: "v1.1.0"},
+	}
+	multierror := &diff.DiffEntry{
+		ModulePath:   "github.com/hashicorp/go-multierror",
+		GoModVersion: &diff.Version{Version: "v1.0.1"},
+	}
+	d := &diff.Diff{
+		Matched:   []*diff.DiffEntry{gversion},
+		NotFound:  []*diff.DiffEntry{xnet},
+		Different: []*diff.DiffEntry{hcl, logrus, multierror},
+		Errored:   []*diff.DiffEntry{},
+		Accepted:  []*diff.DiffEntry{},
+	}
+
+	p := &Policy{
+		Accepted: []*Acceptance{
+			{"github.com/hashicorp/hcl", "v1.0.0", "Upgraded to get parser fixes"},
+			{"golang.org/x/*", "", "Tracked separately"},
+			{"github.com/sirupsen/logrus", "v1.2.0", "Needed for hooks"},
+			{"github.com/removed/module", "v0.1.0", "No longer used"},
+			{"github.com/hashicorp/go-*", "v1.0.0", "Pinned to older API"},
+			{"github.com/sirupsen/*", "v1.3.0", "Needed for hooks"},
+			{"github.com/hashicorp/hcl", "", "Upgraded"},
+		},
+	}
+
+	stale := p.Apply(d)
+
+	if len(d.Accepted) != 2 || d.Accepted[0] != hcl || d.Accepted[1] != xnet {
+		t.Fatalf("Expected hcl and x/net to be accepted, given: %#v", d.Accepted)
+	}
+	if hcl.Justification != "Upgraded to get parser fixes" {
+		t.Fatalf("Unexpected justification: %q", hcl.Justification)
+	}
+	if len(d.Different) != 2 || d.Different[0] != logrus || d.Different[1] != multierror {
+		t.Fatalf("Expected logrus and go-multierror to remain different, given: %#v", d.Different)
+	}
+	if len(d.NotFound) != 0 {
+		t.Fatalf("Expected no entries not found, given: %#v", d.NotFound)
+	}
+
+	expectedStale := []string{
+		"github.com/sirupsen/logrus @ v1.2.0: accepted version no longer matches go.mod version v1.4.0",
+		"github.com/removed/module @ v0.1.0: module not found in go.mod",
+		// Reason of the first module matching the glob is kept
+		"github.com/hashicorp/go-* @ v1.0.0: go.mod version matches govendor, no difference to accept",
+		"github.com/sirupsen/* @ v1.3.0: accepted version no longer matches go.mod version v1.4.0",
+		"github.com/hashicorp/hcl: module is already accepted by an earlier acceptance",
+	}
+	if len(stale) != len(expectedStale) {
+		t.Fatalf("Expected %d stale acceptances, given: %d", len(expectedStale), len(stale))
+	}
+	for i, sa := range stale {
+		if sa.String() != expectedStale[i] {
+			t.Fatalf("Expected %q, given: %q", expectedStale[i], sa.String())
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package report
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/github"
+	"github.com/radeksimko/go-mod-diff/gomod"
+	"github.com/radeksimko/go-mod-diff/policy"
+)
+
+type htmlReport struct {
+	Total    int
+	Matched  int
+	Buckets  []*htmlBucket
+	Warnings []*diff.DiffEntry
+	Stale    []*policy.StaleAcceptance
+}
+
+type htmlBucket struct {
+	ID      string
+	Title   string
+	Entries []*htmlEntry
+}
+
+type htmlEntry struct {
+	*diff.DiffEntry
+	GoModURL         string
+	ReplacementURL   string
+	GoVendorVersions []*htmlVersion
+	ResolvedSHA      string
+	Why              *Why
+}
+
+type htmlVersion struct {
+	*diff.Version
+	TreeURL    string
+	CompareURL string
+	IsMatching bool
+}
+
+// WriteHTML writes the report as a single self-contained HTML page
+func WriteHTML(w io.Writer, r *Report) error {
+	d := r.Diff
+	hr := &htmlReport{
+		Total:   r.Total,
+		Matched: len(d.Matched),
+		Buckets: []*htmlBucket{
+			newHTMLBucket("errored", "Errored", d.Errored, r.Why),
+			newHTMLBucket("not-found", "Not found", d.NotFound, r.Why),
+			newHTMLBucket("different", "Different", d.Different, r.Why),
+			newHTMLBucket("accepted", "Accepted", d.Accepted, r.Why),
+			newHTMLBucket("matched", "Matched", d.Matched, r.Why),
+		},
+		Warnings: d.Warnings(),
+		Stale:    r.StaleAcceptances,
+	}
+
+	return htmlTemplate.Execute(w, hr)
+}
+
+func newHTMLBucket(id, title string, entries []*diff.DiffEntry, why map[string]*Why) *htmlBucket {
+	b := &htmlBucket{
+		ID:      id,
+		Title:   title,
+		Entries: make([]*htmlEntry, 0),
+	}
+	for _, entry := range entries {
+		b.Entries = append(b.Entries, newHTMLEntry(entry, why[entry.ModulePath]))
+	}
+	return b
+}
+
+func newHTMLEntry(de *diff.DiffEntry, why *Why) *htmlEntry {
+	he := &htmlEntry{
+		DiffEntry:        de,
+		GoModURL:         de.GithubTreeURL(),
+		GoVendorVersions: make([]*htmlVersion, 0),
+		ResolvedSHA:      ShortRevision(resolvedSHA(de)),
+		Why:              why,
+	}
+
+	if de.GoModReplacement != nil {
+		he.ReplacementURL = de.VersionTreeURL(de.GoModReplacement)
+	}
+
+	resolved := de.ResolvedGoModVersion()
+	var goModRef string
+	if ref, err := gomod.ParseRefFromVersion(resolved.Version); err == nil {
+		goModRef = ref.String()
+	}
+
+	for _, v := range de.GoVendorVersions {
+		hv := &htmlVersion{
+			Version:    v,
+			IsMatching: v.IsEqual(resolved) || v.IsEqual(de.GithubVersion),
+		}
+		if repo := de.VersionRepository(v); repo != nil {
+			hv.TreeURL = github.TreeURL(repo, v.Revision)
+			// Comparison across forks would need owner prefixes
+			if goModRef != "" && !hv.IsMatching && v.Origin == resolved.Origin {
+				hv.CompareURL = github.CompareURL(repo, v.Revision, goModRef)
+			}
+		}
+		he.GoVendorVersions = append(he.GoVendorVersions, hv)
+	}
+
+	return he
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"short": ShortRevision,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>go-mod-diff report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292e; }
+h2 { margin-top: 2em; }
+table { border-collapse: collapse; width: 100%; font-size: 14px; }
+th, td { border: 1px solid #e1e4e8; padding: 6px 10px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; cursor: pointer; user-select: none; }
+th.sorted-asc::after { content: " \25B2"; }
+th.sorted-desc::after { content: " \25BC"; }
+code { font-family: SFMono-Regular, Consolas, Menlo, monospace; font-size: 13px; }
+.matching { color: #22863a; }
+.error { color: #cb2431; }
+.warning { color: #b08800; }
+.muted { color: #6a737d; }
+.impact-major { color: #cb2431; font-weight: bold; }
+.impact-minor { color: #b08800; font-weight: bold; }
+.impact-patch { color: #22863a; }
+.impact-prerelease { color: #0366d6; }
+.impact-pseudo { color: #6f42c1; }
+#search { font-size: 16px; padding: 6px 10px; width: 40em; max-width: 100%; }
+#errored h2, #not-found h2 { color: #cb2431; }
+#different h2 { color: #b08800; }
+#matched h2, #accepted h2 { color: #22863a; }
+ol.chain { margin: 0.5em 0; padding-left: 1.5em; }
+</style>
+</head>
+<body>
+<h1>go-mod-diff report</h1>
+<p>Matched <strong>{{.Matched}}</strong> of {{.Total}} requirements.</p>
+<p><input id="search" type="search" placeholder="Filter by module path"></p>
+{{range .Buckets}}
+<section id="{{.ID}}">
+<h2>{{.Title}} (<span class="count">{{len .Entries}}</span>)</h2>
+{{if .Entries}}
+<table class="sortable">
+<thead>
+<tr><th>Module</th><th>go.mod version</th><th>govendor versions</th><th>Resolved SHA</th><th>Notes</th><th>go mod why</th></tr>
+</thead>
+<tbody>
+{{range .Entries}}
+<tr data-module="{{.ModulePath}}">
+<td><code>{{.ModulePath}}</code></td>
+<td>{{if .GoModURL}}<a href="{{.GoModURL}}"><code>{{.GoModVersion.Version}}</code></a>{{else}}<code>{{.GoModVersion.Version}}</code>{{end}}
+{{if .GoModReplacement}}<div>=&gt; {{if .ReplacementURL}}<a href="{{.ReplacementURL}}"><code>{{.GoModReplacement.Version}}</code></a>{{else}}<code>{{.GoModReplacement.Version}}</code>{{end}}
+{{if .GoModReplacement.Origin}}<span class="warning">from {{.GoModReplacement.Origin}}</span>{{end}}</div>{{end}}</td>
+<td>{{range .GoVendorVersions}}<div{{if .IsMatching}} class="matching"{{end}}>
+{{if .TreeURL}}<a href="{{.TreeURL}}"><code>{{short .Revision}}</code></a>{{else}}<code>{{short .Revision}}</code>{{end}}
+{{if .Origin}}<span class="warning">from {{.Origin}}</span>{{end}}
+{{if .Time}}<span class="muted">{{.Time}}</span>{{end}}
+{{if .CompareURL}}<a href="{{.CompareURL}}">compare</a>{{end}}
+</div>{{else}}<span class="error">not found</span>{{end}}</td>
+<td><code>{{.ResolvedSHA}}</code></td>
+<td>
+{{with .Impact}}<div class="impact-{{.Kind}}">{{.String}}</div>{{end}}
+{{if .Justification}}<div>Accepted: {{.Justification}}</div>{{end}}
+{{if .Error}}<div class="error">{{.Error}}</div>{{end}}
+{{range .Warnings}}<div class="warning">{{.Message}}</div>{{end}}
+{{with .TagSuggestion}}{{$approximate := .Approximate}}{{with .Suggested}}<div>Suggested tag: <code>{{.String}}</code>{{if $approximate}} (approximate){{end}}</div>{{end}}{{end}}
+{{with .Reconciliation}}<div>Proposed target: <code>{{short .Target.Revision}}</code></div>{{end}}
+</td>
+<td>{{with .Why}}
+{{if .Error}}<span class="error">Failed to check: {{.Error}}</span>
+{{else if .Chains}}<details><summary>{{len .Chains}} import chain(s)</summary>
+{{range .Chains}}<ol class="chain">{{range .}}<li>{{if .GithubURL}}<a href="{{.GithubURL}}">{{.Path}}</a>{{else}}{{.Path}}{{end}}{{if .Version}} <span class="muted">@ {{.Version}}</span>{{end}}</li>{{end}}</ol>{{end}}
+</details>
+{{else}}<span class="error">Package not needed (try <code>go mod tidy</code>)</span>{{end}}
+{{end}}</td>
+</tr>
+{{end}}
+</tbody>
+</table>
+{{end}}
+</section>
+{{end}}
+{{if .Warnings}}
+<section id="warnings">
+<h2 class="warning">Repository warnings ({{len .Warnings}})</h2>
+<ul>
+{{range .Warnings}}{{$path := .ModulePath}}{{range .Warnings}}<li data-module="{{$path}}"><code>{{$path}}</code>: {{.Message}}</li>
+{{end}}{{end}}
+</ul>
+</section>
+{{end}}
+{{if .Stale}}
+<section id="stale">
+<h2 class="warning">Stale accepted differences ({{len .Stale}})</h2>
+<ul>
+{{range .Stale}}<li data-module="{{.Acceptance.Module}}"><code>{{.Acceptance.String}}</code>: {{.Reason}}</li>
+{{end}}
+</ul>
+</section>
+{{end}}
+<script>
+(function() {
+  var search = document.getElementById("search");
+  search.addEventListener("input", function() {
+    var query = search.value.toLowerCase();
+    var sections = document.querySelectorAll("section");
+    for (var i = 0; i < sections.length; i++) {
+      var rows = sections[i].querySelectorAll("[data-module]");
+      var visible = 0;
+      for (var j = 0; j < rows.length; j++) {
+        var matches = rows[j].getAttribute("data-module").toLowerCase().indexOf(query) !== -1;
+        rows[j].style.display = matches ? "" : "none";
+        if (matches) { visible++; }
+      }
+      var count = sections[i].querySelector(".count");
+      if (count) { count.textContent = visible; }
+    }
+  });
+
+  var headers = document.querySelectorAll("table.sortable th");
+  for (var i = 0; i < headers.length; i++) {
+    headers[i].addEventListener("click", function(e) {
+      var th = e.currentTarget;
+      var table = th.closest("table");
+      var tbody = table.querySelector("tbody");
+      var index = Array.prototype.indexOf.call(th.parentNode.children, th);
+      var asc = !th.classList.contains("sorted-asc");
+      var siblings = th.parentNode.children;
+      for (var k = 0; k < siblings.length; k++) {
+        siblings[k].classList.remove("sorted-asc", "sorted-desc");
+      }
+      th.classList.add(asc ? "sorted-asc" : "sorted-desc");
+      var rows = Array.prototype.slice.call(tbody.querySelectorAll("tr"));
+      rows.sort(function(a, b) {
+        var x = a.children[index].textContent.trim();
+        var y = b.children[index].textContent.trim();
+        return asc ? x.localeCompare(y) : y.localeCompare(x);
+      });
+      for (var k = 0; k < rows.length; k++) {
+        tbody.appendChild(rows[k]);
+      }
+    });
+  }
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+)
+
+func TestWriteHTML(t *testing.T) {
+	r := &Report{
+		Diff: &diff.Diff{
+			Different: []*diff.DiffEntry{
+				{
+					ModulePath:   "github.com/hashicorp/hcl",
+					GoModVersion: &diff.Version{Version: "v1.0.0"},
+					GoVendorVersions: []*diff.Version{
+						{Version: "65a6292f0157eff210d03ed1bf6c59b190b8b906", Revision: "65a6292f0157eff210d03ed1bf6c59b190b8b906"},
+					},
+				},
+			},
+		},
+		Total: 1,
+		Why: map[string]*Why{
+			"github.com/hashicorp/hcl": {
+				Chains: [][]*WhyNode{
+					{
+						{Path: "github.com/hashicorp/terraform"},
+						{Path: "github.com/hashicorp/hcl", Version: "v1.0.0", GithubURL: "https://github.com/hashicorp/hcl/tree/v1.0.0"},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteHTML(&buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+
+	expectedSnippets := []string{
+		`<tr data-module="github.com/hashicorp/hcl">`,
+		`<a href="https://github.com/hashicorp/hcl/tree/v1.0.0"><code>v1.0.0</code></a>`,
+		`<a href="https://github.com/hashicorp/hcl/compare/65a6292f0157eff210d03ed1bf6c59b190b8b906...v1.0.0">compare</a>`,
+		`<details><summary>1 import chain(s)</summary>`,
+	}
+	for _, snippet := range expectedSnippets {
+		if !strings.Contains(output, snippet) {
+			t.Fatalf("Expected output to contain %q, given:\n%s", snippet, output)
+		}
+	}
+
+	for _, external := range []string{"<link", "<script src"} {
+		if strings.Contains(output, external) {
+			t.Fatalf("Expected no external assets, found %q", external)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/policy"
+)
+
+// SchemaVersion is bumped on every backwards incompatible change
+// of the JSON output
+const SchemaVersion = 1
+
+type jsonReport struct {
+	SchemaVersion int          `json:"schema_version"`
+	Summary       *jsonSummary `json:"summary"`
+	*diff.Diff
+	Why              map[string]*Why                 `json:"why"`
+	GovendorWhy      map[string]*Why                 `json:"govendor_why,omitempty"`
+	Packages         map[string][]*diff.PackageEntry `json:"packages,omitempty"`
+	StaleAcceptances []*policy.StaleAcceptance       `json:"stale_acceptances"`
+}
+
+type jsonSummary struct {
+	Total     int `json:"total"`
+	Matched   int `json:"matched"`
+	NotFound  int `json:"not_found"`
+	Different int `json:"different"`
+	Errored   int `json:"errored"`
+	Accepted  int `json:"accepted"`
+	Warnings  int `json:"warnings"`
+}
+
+func WriteJSON(w io.Writer, r *Report) error {
+	jr := &jsonReport{
+		SchemaVersion: SchemaVersion,
+		Summary: &jsonSummary{
+			Total:     r.Total,
+			Matched:   len(r.Diff.Matched),
+			NotFound:  len(r.Diff.NotFound),
+			Different: len(r.Diff.Different),
+			Errored:   len(r.Diff.Errored),
+			Accepted:  len(r.Diff.Accepted),
+			Warnings:  len(r.Diff.Warnings()),
+		},
+		Diff: r.Diff,
+		Why:  r.Why,
+
+		GovendorWhy: r.GovendorWhy,
+
+		StaleAcceptances: r.StaleAcceptances,
+	}
+	if r.Granularity == GranularityPackage {
+		jr.Packages = make(map[string][]*diff.PackageEntry, 0)
+		for _, bucket := range [][]*diff.DiffEntry{r.Diff.Different, r.Diff.Accepted, r.Diff.Matched} {
+			for _, entry := range bucket {
+				jr.Packages[entry.ModulePath] = entry.PackageEntries()
+			}
+		}
+	}
+	if jr.StaleAcceptances == nil {
+		jr.StaleAcceptances = make([]*policy.StaleAcceptance, 0)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jr)
+}
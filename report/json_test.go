@@ -0,0 +1,102 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+)
+
+func TestWriteJSON(t *testing.T) {
+	r := &Report{
+		Diff: &diff.Diff{
+			Matched:   []*diff.DiffEntry{},
+			NotFound:  []*diff.DiffEntry{},
+			Different: []*diff.DiffEntry{},
+			Errored: []*diff.DiffEntry{
+				{
+					ModulePath:   "github.com/hashicorp/hcl",
+					GoModVersion: &diff.Version{Version: "v1.0.0"},
+				},
+			},
+		},
+		Total: 1,
+		Why: map[string]*Why{
+			"github.com/hashicorp/hcl": {
+				Chains: [][]*WhyNode{
+					{
+						{Path: "github.com/hashicorp/terraform"},
+						{Path: "github.com/hashicorp/hcl", Version: "v1.0.0"},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteJSON(&buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output map[string]interface{}
+	err = json.Unmarshal(buf.Bytes(), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := output["schema_version"]; v != float64(SchemaVersion) {
+		t.Fatalf("Expected schema version %d, given: %v", SchemaVersion, v)
+	}
+	for _, bucket := range []string{"matched", "not_found", "different", "errored"} {
+		if _, ok := output[bucket].([]interface{}); !ok {
+			t.Fatalf("Expected %q bucket to be a list, given: %#v", bucket, output[bucket])
+		}
+	}
+	why := output["why"].(map[string]interface{})["github.com/hashicorp/hcl"].(map[string]interface{})
+	if chains := why["chains"].([]interface{}); len(chains) != 1 {
+		t.Fatalf("Expected 1 go mod why chain, given: %#v", chains)
+	}
+}
+
+func TestWriteJSON_packageGranularity(t *testing.T) {
+	r := &Report{
+		Diff: &diff.Diff{
+			Different: []*diff.DiffEntry{
+				{
+					ModulePath:   "golang.org/x/net",
+					GoModVersion: &diff.Version{Version: "v0.0.0-20180416091243-3b0461eec859", Revision: "3b0461eec859"},
+					GoVendorVersions: []*diff.Version{
+						{
+							Version:  "1c05540f6879653db88113bc4a2b70aec4bd491f",
+							Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f",
+							Packages: []string{"golang.org/x/net/http2"},
+						},
+					},
+				},
+			},
+		},
+		Total:       1,
+		Granularity: GranularityPackage,
+	}
+
+	var buf bytes.Buffer
+	err := WriteJSON(&buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output struct {
+		Packages map[string][]*diff.PackageEntry `json:"packages"`
+	}
+	err = json.Unmarshal(buf.Bytes(), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgs := output.Packages["golang.org/x/net"]
+	if len(pkgs) != 1 || pkgs[0].Package != "golang.org/x/net/http2" || pkgs[0].Matched {
+		t.Fatalf("Unexpected packages: %#v", pkgs)
+	}
+}
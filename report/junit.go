@@ -0,0 +1,197 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name          `xml:"testsuites"`
+	Suites  []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+const junitClassName = "go.mod"
+
+// WriteJUnit writes the report as JUnit XML where each go.mod requirement is a test case
+func WriteJUnit(w io.Writer, r *Report) error {
+	d := r.Diff
+	suite := &junitTestSuite{
+		Name:      "go-mod-diff",
+		TestCases: make([]*junitTestCase, 0),
+	}
+
+	for _, entry := range d.Errored {
+		suite.TestCases = append(suite.TestCases, &junitTestCase{
+			Name:      entry.ModulePath,
+			ClassName: junitClassName,
+			Error: &junitMessage{
+				Message: entryError(entry),
+				Type:    "errored",
+				Body:    junitDetails(entry, r.Why[entry.ModulePath]),
+			},
+		})
+		suite.Errors++
+	}
+
+	for _, entry := range d.NotFound {
+		suite.TestCases = append(suite.TestCases, &junitTestCase{
+			Name:      entry.ModulePath,
+			ClassName: junitClassName,
+			Failure: &junitMessage{
+				Message: fmt.Sprintf("%s not found in govendor", entry.GoModVersion.Version),
+				Type:    "not_found",
+				Body:    junitDetails(entry, r.Why[entry.ModulePath]),
+			},
+		})
+		suite.Failures++
+	}
+
+	for _, entry := range d.Different {
+		suite.TestCases = append(suite.TestCases, &junitTestCase{
+			Name:      entry.ModulePath,
+			ClassName: junitClassName,
+			Failure: &junitMessage{
+				Message: fmt.Sprintf("go.mod version %s differs from govendor %s",
+					entry.GoModVersion.Version, strings.Join(govendorRevisions(entry), ", ")),
+				Type: "different",
+				Body: junitDetails(entry, r.Why[entry.ModulePath]),
+			},
+		})
+		suite.Failures++
+	}
+
+	for _, entry := range d.Accepted {
+		suite.TestCases = append(suite.TestCases, &junitTestCase{
+			Name:      entry.ModulePath,
+			ClassName: junitClassName,
+			SystemOut: fmt.Sprintf("accepted: %s\n%s", entry.Justification, junitDetails(entry, nil)),
+		})
+	}
+
+	for _, entry := range d.Matched {
+		suite.TestCases = append(suite.TestCases, &junitTestCase{
+			Name:      entry.ModulePath,
+			ClassName: junitClassName,
+			SystemOut: junitDetails(entry, nil),
+		})
+	}
+
+	suite.Tests = len(suite.TestCases)
+	suites := &junitTestSuites{Suites: []*junitTestSuite{suite}}
+
+	if len(r.StaleAcceptances) > 0 {
+		policySuite := &junitTestSuite{
+			Name:      "go-mod-diff-policy",
+			TestCases: make([]*junitTestCase, 0),
+		}
+		for _, sa := range r.StaleAcceptances {
+			policySuite.TestCases = append(policySuite.TestCases, &junitTestCase{
+				Name:      sa.Acceptance.String(),
+				ClassName: "policy",
+				Failure: &junitMessage{
+					Message: sa.Reason,
+					Type:    "stale",
+					Body:    sa.Acceptance.Justification,
+				},
+			})
+		}
+		policySuite.Tests = len(policySuite.TestCases)
+		policySuite.Failures = len(policySuite.TestCases)
+		suites.Suites = append(suites.Suites, policySuite)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(suites)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func junitDetails(de *diff.DiffEntry, why *Why) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "go modules: %s\n", de.GoModVersion.String())
+	if de.GoModReplacement != nil {
+		fmt.Fprintf(&b, "replaced by: %s\n", de.GoModReplacement.String())
+	}
+	if url := de.GithubTreeURL(); url != "" {
+		fmt.Fprintf(&b, "GitHub: %s\n", url)
+	}
+	if de.GithubVersion != nil {
+		fmt.Fprintf(&b, "GitHub rev: %s\n", de.GithubVersion.String())
+	}
+	if len(de.GoVendorVersions) > 0 {
+		fmt.Fprintf(&b, "govendor:\n")
+		for _, v := range de.GoVendorVersions {
+			fmt.Fprintf(&b, "  %s\n", v.String())
+		}
+	} else {
+		fmt.Fprintf(&b, "govendor: not found\n")
+	}
+	for _, w := range de.Warnings {
+		fmt.Fprintf(&b, "warning: %s\n", w.Message)
+	}
+
+	if why != nil {
+		if why.Error != "" {
+			fmt.Fprintf(&b, "go mod why: failed to check: %s\n", strings.TrimSpace(why.Error))
+		} else if len(why.Chains) == 0 {
+			fmt.Fprintf(&b, "go mod why: package not needed (try `go mod tidy`)\n")
+		}
+		for _, chain := range why.Chains {
+			fmt.Fprintf(&b, "go mod why:\n")
+			for _, node := range chain {
+				fmt.Fprintf(&b, "  %s\n", node.String())
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func entryError(de *diff.DiffEntry) string {
+	if de.Error == nil {
+		return "unknown error"
+	}
+	return de.Error.Error()
+}
+
+func govendorRevisions(de *diff.DiffEntry) []string {
+	revs := make([]string, 0)
+	for _, v := range de.GoVendorVersions {
+		revs = append(revs, ShortRevision(v.Revision))
+	}
+	return revs
+}
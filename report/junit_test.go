@@ -0,0 +1,89 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	r := &Report{
+		Diff: &diff.Diff{
+			Matched: []*diff.DiffEntry{
+				{
+					ModulePath:   "golang.org/x/xerrors",
+					GoModVersion: &diff.Version{Version: "v0.0.0-20191011141410-1b5146add898", Revision: "1b5146add898"},
+				},
+			},
+			NotFound: []*diff.DiffEntry{
+				{
+					ModulePath:   "github.com/hashicorp/hcl",
+					GoModVersion: &diff.Version{Version: "v1.0.0"},
+				},
+			},
+			Different: []*diff.DiffEntry{
+				{
+					ModulePath:   "golang.org/x/net",
+					GoModVersion: &diff.Version{Version: "v0.0.0-20180413091243-1c05540f6879", Revision: "1c05540f6879"},
+					GoVendorVersions: []*diff.Version{
+						{Version: "3b0461eec859c4b73bb64fdc8285971fd33e3938", Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938"},
+					},
+				},
+			},
+			Errored: []*diff.DiffEntry{
+				{
+					ModulePath:   "github.com/hashicorp/terraform",
+					GoModVersion: &diff.Version{Version: "v0.11.11"},
+					Error:        fmt.Errorf("Failed to get ref SHA from GitHub: boom"),
+				},
+			},
+		},
+		Total: 4,
+		Why:   map[string]*Why{},
+	}
+
+	var buf bytes.Buffer
+	err := WriteJUnit(&buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var suites junitTestSuites
+	err = xml.Unmarshal(buf.Bytes(), &suites)
+	if err != nil {
+		t.Fatalf("Failed to parse output: %s\n%s", err, buf.String())
+	}
+
+	suite := suites.Suites[0]
+	if suite.Tests != 4 || suite.Failures != 2 || suite.Errors != 1 {
+		t.Fatalf("Unexpected counts (tests: %d, failures: %d, errors: %d)",
+			suite.Tests, suite.Failures, suite.Errors)
+	}
+
+	expectedCases := map[string]string{
+		"github.com/hashicorp/terraform": "error",
+		"github.com/hashicorp/hcl":       "failure",
+		"golang.org/x/net":               "failure",
+		"golang.org/x/xerrors":           "pass",
+	}
+	for _, tc := range suite.TestCases {
+		result := "pass"
+		if tc.Failure != nil {
+			result = "failure"
+		}
+		if tc.Error != nil {
+			result = "error"
+		}
+		if expectedCases[tc.Name] != result {
+			t.Fatalf("Expected %s to be %q, given: %q", tc.Name, expectedCases[tc.Name], result)
+		}
+	}
+
+	expectedMessage := "go.mod version v0.0.0-20180413091243-1c05540f6879 differs from govendor 3b0461eec859"
+	if msg := suite.TestCases[2].Failure.Message; msg != expectedMessage {
+		t.Fatalf("Expected message %q, given: %q", expectedMessage, msg)
+	}
+}
@@ -0,0 +1,195 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/github"
+)
+
+// WriteMarkdown writes the report as markdown suitable for pull request descriptions
+func WriteMarkdown(w io.Writer, r *Report) error {
+	bw := bufio.NewWriter(w)
+	d := r.Diff
+
+	fmt.Fprintf(bw, "## Dependency differences\n\n")
+	fmt.Fprintf(bw, "Matched **%d** of %d requirements "+
+		"(%d not found, %d different, %d errored, %d accepted).\n",
+		len(d.Matched), r.Total, len(d.NotFound), len(d.Different), len(d.Errored), len(d.Accepted))
+
+	writeMarkdownBucket(bw, "Errored", d.Errored, r.Why)
+	writeMarkdownBucket(bw, "Not found", d.NotFound, r.Why)
+	writeMarkdownBucket(bw, "Different", d.Different, r.Why)
+	writeMarkdownBucket(bw, "Accepted", d.Accepted, r.Why)
+	writeMarkdownBucket(bw, "Matched", d.Matched, r.Why)
+
+	if len(d.DuplicateMajors) > 0 {
+		fmt.Fprintf(bw, "\n### Duplicate major versions\n\n")
+		for _, dm := range d.DuplicateMajors {
+			versions := make([]string, 0)
+			for _, entry := range dm.Entries {
+				versions = append(versions, fmt.Sprintf("`%s` %s", entry.ModulePath, entry.GoModVersion.Version))
+			}
+			fmt.Fprintf(bw, "- `%s`: %s", dm.Path, strings.Join(versions, ", "))
+			if dm.SingleRevision {
+				fmt.Fprintf(bw, " (govendor pins a single revision)")
+			}
+			fmt.Fprintln(bw)
+		}
+	}
+
+	if warned := d.Warnings(); len(warned) > 0 {
+		fmt.Fprintf(bw, "\n### Repository warnings\n\n")
+		for _, entry := range warned {
+			for _, warning := range entry.Warnings {
+				fmt.Fprintf(bw, "- `%s`: %s\n", entry.ModulePath, warning.Message)
+			}
+		}
+	}
+
+	if len(r.StaleAcceptances) > 0 {
+		fmt.Fprintf(bw, "\n### Stale accepted differences\n\n")
+		for _, sa := range r.StaleAcceptances {
+			fmt.Fprintf(bw, "- `%s`: %s\n", sa.Acceptance, sa.Reason)
+		}
+	}
+
+	return bw.Flush()
+}
+
+func writeMarkdownBucket(w io.Writer, title string, entries []*diff.DiffEntry, why map[string]*Why) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n### %s (%d)\n\n", title, len(entries))
+	fmt.Fprintln(w, "| Module | go.mod version | govendor versions | Resolved SHA | Notes |")
+	fmt.Fprintln(w, "| --- | --- | --- | --- | --- |")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s |\n",
+			entry.ModulePath,
+			markdownGoModVersion(entry),
+			markdownGoVendorVersions(entry),
+			markdownResolvedSHA(entry),
+			markdownNotes(entry))
+	}
+
+	for _, entry := range entries {
+		ew, ok := why[entry.ModulePath]
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n<details>\n<summary><code>go mod why -m %s</code></summary>\n\n",
+			entry.ModulePath)
+		fmt.Fprintln(w, "```")
+		if ew.Error != "" {
+			fmt.Fprintf(w, "Failed to check: %s\n", strings.TrimSpace(ew.Error))
+		} else if len(ew.Chains) == 0 {
+			fmt.Fprintln(w, "Package not needed (try `go mod tidy`)")
+		}
+		for i, chain := range ew.Chains {
+			if i > 0 {
+				fmt.Fprintln(w, "")
+			}
+			for _, node := range chain {
+				fmt.Fprintln(w, node.String())
+			}
+		}
+		fmt.Fprintln(w, "```")
+		fmt.Fprintf(w, "</details>\n")
+	}
+}
+
+func markdownGoModVersion(de *diff.DiffEntry) string {
+	output := fmt.Sprintf("`%s`", de.GoModVersion.Version)
+	if url := de.GithubTreeURL(); url != "" {
+		output = fmt.Sprintf("[%s](%s)", output, url)
+	}
+	if rep := de.GoModReplacement; rep != nil {
+		replacement := fmt.Sprintf("`%s`", rep.Version)
+		if url := de.VersionTreeURL(rep); url != "" {
+			replacement = fmt.Sprintf("[%s](%s)", replacement, url)
+		}
+		output += " => " + replacement
+		if rep.Origin != "" {
+			output += fmt.Sprintf(" from `%s`", rep.Origin)
+		}
+	}
+	return output
+}
+
+func markdownGoVendorVersions(de *diff.DiffEntry) string {
+	if len(de.GoVendorVersions) == 0 {
+		return "_not found_"
+	}
+
+	versions := make([]string, 0)
+	for _, v := range de.GoVendorVersions {
+		version := fmt.Sprintf("`%s`", ShortRevision(v.Revision))
+		if repo := de.VersionRepository(v); repo != nil {
+			version = fmt.Sprintf("[%s](%s)", version, github.TreeURL(repo, v.Revision))
+		}
+		if v.Origin != "" {
+			version += fmt.Sprintf(" from `%s`", v.Origin)
+		}
+		if v.Time != "" {
+			version += fmt.Sprintf(" (%s)", v.Time)
+		}
+		versions = append(versions, version)
+	}
+	return strings.Join(versions, "<br>")
+}
+
+func markdownResolvedSHA(de *diff.DiffEntry) string {
+	if sha := resolvedSHA(de); sha != "" {
+		return fmt.Sprintf("`%s`", ShortRevision(sha))
+	}
+	return ""
+}
+
+func markdownNotes(de *diff.DiffEntry) string {
+	notes := make([]string, 0)
+	if impact := de.Impact(); impact != nil {
+		notes = append(notes, fmt.Sprintf("**%s** change (`%s` → `%s`)", impact.Kind, impact.From, impact.To))
+	}
+	if de.Justification != "" {
+		notes = append(notes, fmt.Sprintf("Accepted: %s", markdownEscape(de.Justification)))
+	}
+	if de.Error != nil {
+		notes = append(notes, markdownEscape(de.Error.Error()))
+	}
+	for _, w := range de.Warnings {
+		notes = append(notes, markdownEscape(w.Message))
+	}
+	if de.TagSuggestion != nil {
+		if s := de.TagSuggestion.Suggested(); s != nil {
+			note := fmt.Sprintf("Suggested tag: `%s`", s.String())
+			if de.TagSuggestion.Approximate {
+				note += " (approximate)"
+			}
+			notes = append(notes, note)
+		}
+	}
+	if de.Reconciliation != nil {
+		notes = append(notes, fmt.Sprintf("Proposed target: `%s`",
+			ShortRevision(de.Reconciliation.Target.Revision)))
+	}
+	return strings.Join(notes, "<br>")
+}
+
+// resolvedSHA returns the revision go.mod version resolves to, if known
+func resolvedSHA(de *diff.DiffEntry) string {
+	if de.GithubVersion != nil {
+		return de.GithubVersion.Revision
+	}
+	return de.ResolvedGoModVersion().Revision
+}
+
+func markdownEscape(s string) string {
+	s = strings.Replace(s, "|", "\\|", -1)
+	return strings.Replace(s, "\n", " ", -1)
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	r := &Report{
+		Diff: &diff.Diff{
+			NotFound: []*diff.DiffEntry{
+				{
+					ModulePath:   "github.com/hashicorp/hcl",
+					GoModVersion: &diff.Version{Version: "v1.0.0"},
+				},
+			},
+			Different: []*diff.DiffEntry{
+				{
+					ModulePath:   "golang.org/x/net",
+					GoModVersion: &diff.Version{Version: "v0.0.0-20180413091243-1c05540f6879", Revision: "1c05540f6879"},
+					GoVendorVersions: []*diff.Version{
+						{Version: "3b0461eec859c4b73bb64fdc8285971fd33e3938", Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938"},
+					},
+				},
+			},
+		},
+		Total: 2,
+		Why: map[string]*Why{
+			"github.com/hashicorp/hcl": {
+				Chains: [][]*WhyNode{
+					{
+						{Path: "github.com/hashicorp/terraform"},
+						{Path: "github.com/hashicorp/hcl", Version: "v1.0.0"},
+					},
+				},
+			},
+			"golang.org/x/net": {Chains: [][]*WhyNode{}},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteMarkdown(&buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := buf.String()
+
+	expectedSnippets := []string{
+		"Matched **0** of 2 requirements (1 not found, 1 different, 0 errored, 0 accepted).",
+		"### Not found (1)",
+		"| `github.com/hashicorp/hcl` | [`v1.0.0`](https://github.com/hashicorp/hcl/tree/v1.0.0) | _not found_ |  |  |",
+		"| `golang.org/x/net` | `v0.0.0-20180413091243-1c05540f6879` | `3b0461eec859` | `1c05540f6879` |  |",
+		"<summary><code>go mod why -m github.com/hashicorp/hcl</code></summary>",
+		"github.com/hashicorp/terraform\ngithub.com/hashicorp/hcl @ v1.0.0\n",
+		"Package not needed (try `go mod tidy`)",
+	}
+	for _, snippet := range expectedSnippets {
+		if !strings.Contains(output, snippet) {
+			t.Fatalf("Expected output to contain %q, given:\n%s", snippet, output)
+		}
+	}
+	if strings.Contains(output, "### Matched") {
+		t.Fatalf("Expected empty buckets to be omitted, given:\n%s", output)
+	}
+}
@@ -0,0 +1,166 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/github"
+	"github.com/radeksimko/go-mod-diff/gomod"
+	"github.com/radeksimko/go-mod-diff/govendor"
+	"github.com/radeksimko/go-mod-diff/policy"
+	"golang.org/x/mod/modfile"
+)
+
+// Report is the diff along with context needed to render it
+type Report struct {
+	Diff *diff.Diff
+	// Total is the number of requirements in go.mod
+	Total int
+	// Why contains `go mod why` results of all entries which aren't matched
+	Why map[string]*Why
+	// GovendorWhy explains why govendor needed packages of different entries,
+	// only populated via GovendorWhy
+	GovendorWhy map[string]*Why
+	// StaleAcceptances are accepted differences from policy which no longer apply
+	StaleAcceptances []*policy.StaleAcceptance
+	// Granularity controls whether vendored packages of modules are listed individually
+	Granularity Granularity
+}
+
+type Granularity string
+
+const (
+	GranularityModule  Granularity = "module"
+	GranularityPackage Granularity = "package"
+)
+
+// Why is the result of `go mod why` for a single module
+type Why struct {
+	Chains [][]*WhyNode `json:"chains"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// WhyNode is a module in the import chain
+type WhyNode struct {
+	Path      string `json:"path"`
+	Version   string `json:"version,omitempty"`
+	GithubURL string `json:"github_url,omitempty"`
+}
+
+func (wn *WhyNode) String() string {
+	output := wn.Path
+	if wn.Version != "" {
+		output += " @ " + wn.Version
+	}
+	if wn.GithubURL != "" {
+		output += fmt.Sprintf(" (%s)", wn.GithubURL)
+	}
+	return output
+}
+
+func New(d *diff.Diff, goModFile *modfile.File) *Report {
+	r := &Report{
+		Diff:  d,
+		Total: len(goModFile.Require),
+		Why:   make(map[string]*Why, 0),
+
+		Granularity: GranularityModule,
+
+		StaleAcceptances: make([]*policy.StaleAcceptance, 0),
+	}
+
+	// Most serious changes first
+	diff.SortByImpact(d.Different)
+
+	paths := make([]string, 0)
+	for _, bucket := range [][]*diff.DiffEntry{d.Errored, d.NotFound, d.Different} {
+		for _, entry := range bucket {
+			paths = append(paths, entry.ModulePath)
+		}
+	}
+	if len(paths) > 0 {
+		r.Why = ModulesWhy(goModFile, paths)
+	}
+
+	return r
+}
+
+// GovendorWhy explains why govendor needed packages of different entries,
+// i.e. which packages of the project (transitively) import them
+func GovendorWhy(graph *govendor.ImportGraph, d *diff.Diff) map[string]*Why {
+	whys := make(map[string]*Why, len(d.Different))
+	for _, entry := range d.Different {
+		pkgs := make([]string, 0)
+		for _, v := range entry.GoVendorVersions {
+			pkgs = append(pkgs, v.Packages...)
+		}
+
+		why := &Why{Chains: make([][]*WhyNode, 0)}
+		if pc := graph.Why(pkgs); pc != nil {
+			chain := make([]*WhyNode, 0, len(pc))
+			for _, p := range pc {
+				chain = append(chain, &WhyNode{Path: p})
+			}
+			why.Chains = append(why.Chains, chain)
+		}
+		whys[entry.ModulePath] = why
+	}
+	return whys
+}
+
+// ModulesWhy explains why modules are needed by the main module,
+// computing import chains of all of them from a single package graph
+func ModulesWhy(goModFile *modfile.File, paths []string) map[string]*Why {
+	whys := make(map[string]*Why, len(paths))
+
+	dir := filepath.Dir(goModFile.Syntax.Name)
+	graph, stderr, err := gomod.LoadImportGraph(dir)
+	if err != nil {
+		for _, path := range paths {
+			whys[path] = &Why{
+				Chains: make([][]*WhyNode, 0),
+				Error:  fmt.Sprintf("%s\n%s", err, stderr),
+			}
+		}
+		return whys
+	}
+
+	graph.Resolver = gomod.NewModuleResolver(goModFile)
+
+	vlF := gomod.GetVersionForModule(goModFile)
+	for _, path := range paths {
+		why := &Why{Chains: make([][]*WhyNode, 0)}
+		if mc := graph.ModuleWhy(path); mc != nil {
+			why.Chains = append(why.Chains, whyChain(mc, vlF))
+		}
+		whys[path] = why
+	}
+	return whys
+}
+
+func whyChain(modules []string, vlF gomod.VersionLookupFunc) []*WhyNode {
+	chain := make([]*WhyNode, 0)
+	for _, t := range modules {
+		node := &WhyNode{Path: t, Version: vlF(t)}
+		if node.Version != "" {
+			repo, err := github.ParseRepositoryURL(t)
+			if err == nil {
+				ref, err := gomod.ParseRefFromVersion(node.Version)
+				if err == nil {
+					node.GithubURL = github.TreeURL(repo, ref.String())
+				}
+			}
+		}
+		chain = append(chain, node)
+	}
+	return chain
+}
+
+// ShortRevision abbreviates the revision to 12 characters, as in pseudo-versions
+func ShortRevision(rev string) string {
+	if len(rev) > 12 {
+		return rev[0:12]
+	}
+	return rev
+}
@@ -0,0 +1,345 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mitchellh/colorstring"
+	"github.com/radeksimko/go-mod-diff/diff"
+	"github.com/radeksimko/go-mod-diff/policy"
+)
+
+// TextWriter writes human-readable output, optionally colorized
+type TextWriter struct {
+	w        io.Writer
+	colorize *colorstring.Colorize
+
+	// diag receives warnings, so that w can be piped elsewhere
+	diag         io.Writer
+	diagColorize *colorstring.Colorize
+
+	// packages lists vendored packages individually instead of govendor versions
+	packages bool
+}
+
+func NewTextWriter(w io.Writer, color bool) *TextWriter {
+	c := NewColorize(color)
+	return &TextWriter{
+		w:            w,
+		colorize:     c,
+		diag:         w,
+		diagColorize: c,
+	}
+}
+
+// SetDiagnostics routes warnings to a separate writer, such as stderr
+func (tw *TextWriter) SetDiagnostics(w io.Writer, color bool) {
+	tw.diag = w
+	tw.diagColorize = NewColorize(color)
+}
+
+// NewColorize returns colorizer of output, which strips colors if color is false
+func NewColorize(color bool) *colorstring.Colorize {
+	return &colorstring.Colorize{
+		Colors:  colorstring.DefaultColors,
+		Disable: !color,
+		Reset:   true,
+	}
+}
+
+// printf colorizes the format (not the arguments) and prints it
+func (tw *TextWriter) printf(format string, a ...interface{}) {
+	fmt.Fprintf(tw.w, tw.colorize.Color(format), a...)
+}
+
+func (tw *TextWriter) diagf(format string, a ...interface{}) {
+	fmt.Fprintf(tw.diag, tw.diagColorize.Color(format), a...)
+}
+
+func (tw *TextWriter) WriteReport(r *Report) error {
+	d := r.Diff
+	tw.packages = r.Granularity == GranularityPackage
+	tw.writeDifference(r)
+	tw.writeDuplicateMajors(d.DuplicateMajors)
+	tw.writeWarnings(d)
+	tw.writeStaleAcceptances(r.StaleAcceptances)
+
+	total := r.Total - len(d.Matched) - len(d.Accepted)
+
+	tw.printf("\n\nMatched package revisions: [bold][green]%d[reset] of %d.\n"+
+		"[bold]%d[reset] to check ([bold][red]%d[reset] not found and [bold][yellow]%d[reset] different revs).\n",
+		len(d.Matched), r.Total, total, len(d.NotFound), len(d.Different))
+
+	if len(d.Accepted) > 0 {
+		tw.printf("[bold][cyan]%d[reset] accepted differences.\n", len(d.Accepted))
+	}
+	if tw.packages {
+		tw.writePackagesSummary(d)
+	}
+
+	if warned := d.Warnings(); len(warned) > 0 {
+		tw.diagf("[bold][yellow]%d[reset] modules with repository warnings.\n", len(warned))
+	}
+
+	return nil
+}
+
+func (tw *TextWriter) writeDifference(r *Report) {
+	d := r.Diff
+	for _, entry := range d.Errored {
+		tw.WriteDiffEntry(entry, r.Why[entry.ModulePath])
+	}
+
+	for _, entry := range d.NotFound {
+		tw.WriteDiffEntry(entry, r.Why[entry.ModulePath])
+	}
+
+	for _, entry := range d.Different {
+		tw.WriteDiffEntry(entry, r.Why[entry.ModulePath])
+		if why, ok := r.GovendorWhy[entry.ModulePath]; ok {
+			tw.writeGovendorWhy(why)
+		}
+	}
+
+	for _, entry := range d.Accepted {
+		tw.printf("\n[bold]%s[reset] [bold][cyan]accepted[reset] @ %s: %s",
+			entry.ModulePath, entry.GoModVersion.Version, entry.Justification)
+	}
+
+	for _, entry := range d.Matched {
+		tw.printf("\n[bold]%s[reset] [bold][green]✓[reset]", entry.ModulePath)
+		if tw.packages {
+			tw.printf("\n")
+			tw.writePackages(entry)
+		}
+		if entry.TagSuggestion != nil {
+			tw.printf("\n")
+			tw.writeTagSuggestion(entry.TagSuggestion)
+		}
+	}
+}
+
+// WriteDiffEntry writes versions and details of a single module
+func (tw *TextWriter) WriteDiffEntry(de *diff.DiffEntry, why *Why) {
+	tw.printf("\n[bold]%s[reset]\n", de.ModulePath)
+
+	tw.printf(" - go modules: %s\n", de.GoModVersion.String())
+	if de.GoModReplacement != nil {
+		tw.printf(" - replaced by: %s\n", de.GoModReplacement.String())
+	}
+
+	if impact := de.Impact(); impact != nil {
+		tw.printf(" - impact: "+impactColors[impact.Kind]+"%s[reset]\n", impact.String())
+	}
+
+	if de.Error != nil {
+		tw.printf(" - [bold][red]Error:[reset] [red]%s[reset]\n", de.Error.Error())
+	}
+
+	if url := de.GithubTreeURL(); url != "" {
+		tw.printf(" - GitHub: %s\n", url)
+	}
+
+	if de.GithubVersion != nil {
+		tw.printf(" - GitHub rev: %s\n", de.GithubVersion.String())
+	}
+
+	if de.TagSuggestion != nil {
+		tw.writeTagSuggestion(de.TagSuggestion)
+	}
+
+	if tw.packages && len(de.GoVendorVersions) > 0 {
+		tw.printf(" - govendor packages:\n")
+		tw.writePackages(de)
+	} else if len(de.GoVendorVersions) > 0 {
+		tw.printf(" - govendor: [\n")
+		for _, gvv := range de.GoVendorVersions {
+			if gvv.IsEqual(de.ResolvedGoModVersion()) || gvv.IsEqual(de.GithubVersion) {
+				tw.printf("       [green]%s\n", gvv.String())
+			} else {
+				tw.printf("       %s\n", gvv.String())
+			}
+		}
+		tw.printf("   ]\n")
+	} else {
+		tw.printf(" - govendor: [red]not found\n")
+	}
+
+	if de.Reconciliation != nil {
+		tw.writeReconciliation(de.Reconciliation)
+	}
+
+	if why != nil {
+		tw.printf(" - go mod why: ")
+		tw.WriteWhy(why)
+	}
+}
+
+// impactColors makes more serious changes stand out
+var impactColors = map[diff.ImpactKind]string{
+	diff.ImpactMajor:      "[bold][red]",
+	diff.ImpactMinor:      "[bold][yellow]",
+	diff.ImpactPatch:      "[green]",
+	diff.ImpactPrerelease: "[cyan]",
+	diff.ImpactPseudo:     "[magenta]",
+}
+
+// writePackages writes every vendored package of the module with its own revision
+func (tw *TextWriter) writePackages(de *diff.DiffEntry) {
+	for _, pe := range de.PackageEntries() {
+		revision := pe.Revision
+		if pe.Origin != "" {
+			revision += " from " + pe.Origin
+		}
+		if pe.Matched {
+			tw.printf("     [green]✓ %s[reset] @ %s\n", pe.Package, revision)
+		} else {
+			tw.printf("     [yellow]✗ %s[reset] @ %s\n", pe.Package, revision)
+		}
+	}
+}
+
+func (tw *TextWriter) writePackagesSummary(d *diff.Diff) {
+	total, matched := 0, 0
+	for _, bucket := range [][]*diff.DiffEntry{d.Different, d.Accepted, d.Matched} {
+		for _, entry := range bucket {
+			for _, pe := range entry.PackageEntries() {
+				total++
+				if pe.Matched {
+					matched++
+				}
+			}
+		}
+	}
+	tw.printf("Matched vendored packages: [bold][green]%d[reset] of %d.\n", matched, total)
+}
+
+func (tw *TextWriter) writeReconciliation(r *diff.Reconciliation) {
+	tw.printf(" - proposed govendor target: [bold][cyan]%s[reset]\n", r.Target.String())
+	for _, m := range r.Moves {
+		tw.printf("   %s moves forward from %s\n", m.Package, m.String())
+	}
+}
+
+func (tw *TextWriter) writeTagSuggestion(ts *diff.TagSuggestion) {
+	suggested := ts.Suggested()
+	if suggested == nil {
+		tw.printf(" - suggested tag: [yellow]no semver tags found near revision\n")
+		return
+	}
+	if ts.Approximate {
+		tw.printf(" - suggested tag: [bold][cyan]%s[reset] [yellow](approximate)[reset]\n", suggested.String())
+	} else {
+		tw.printf(" - suggested tag: [bold][cyan]%s[reset]\n", suggested.String())
+	}
+
+	if ts.Before != nil {
+		tw.printf("   closest tag before: %s\n", ts.Before.String())
+	}
+	if ts.After != nil {
+		tw.printf("   closest tag after: %s\n", ts.After.String())
+	}
+	if len(ts.Containing) > 0 {
+		tw.printf("   tags containing revision: %s\n", strings.Join(ts.Containing, ", "))
+	}
+}
+
+// writeDuplicateMajors writes modules required at several major versions together
+func (tw *TextWriter) writeDuplicateMajors(duplicates []*diff.DuplicateMajor) {
+	if len(duplicates) == 0 {
+		return
+	}
+
+	tw.printf("\n\n[bold][yellow]Duplicate major versions:[reset]\n")
+	for _, dm := range duplicates {
+		tw.printf("\n[bold]%s[reset]", dm.Path)
+		if dm.SingleRevision {
+			tw.printf(" [yellow](govendor pins a single revision)[reset]")
+		}
+		tw.printf("\n")
+		for _, entry := range dm.Entries {
+			revisions := make([]string, 0)
+			for _, v := range entry.GoVendorVersions {
+				revisions = append(revisions, ShortRevision(v.Revision))
+			}
+			govendor := "not found"
+			if len(revisions) > 0 {
+				govendor = strings.Join(revisions, ", ")
+			}
+			tw.printf(" - %s @ %s (govendor: %s)\n", entry.ModulePath, entry.GoModVersion.Version, govendor)
+		}
+	}
+}
+
+// WriteEntryWarnings writes warnings of a single module to diagnostics,
+// for use along with WriteDiffEntry outside of a full report
+func (tw *TextWriter) WriteEntryWarnings(de *diff.DiffEntry) {
+	for _, w := range de.Warnings {
+		tw.diagf(" - [bold][yellow]Warning:[reset] [yellow]%s[reset]\n", w.String())
+	}
+}
+
+func (tw *TextWriter) writeWarnings(d *diff.Diff) {
+	warned := d.Warnings()
+	if len(warned) == 0 {
+		return
+	}
+
+	tw.diagf("\n[bold][yellow]Repository warnings:[reset]\n")
+	for _, entry := range warned {
+		tw.diagf("\n[bold]%s[reset]\n", entry.ModulePath)
+		for _, w := range entry.Warnings {
+			tw.diagf(" - [yellow]%s[reset] (%s)\n", w.String(), w.Kind)
+		}
+	}
+}
+
+func (tw *TextWriter) writeStaleAcceptances(stale []*policy.StaleAcceptance) {
+	if len(stale) == 0 {
+		return
+	}
+
+	tw.diagf("\n[bold][yellow]Stale accepted differences:[reset]\n")
+	for _, sa := range stale {
+		tw.diagf(" - [bold]%s[reset]: [yellow]%s[reset]\n", sa.Acceptance, sa.Reason)
+	}
+}
+
+func (tw *TextWriter) writeGovendorWhy(why *Why) {
+	tw.printf(" - govendor why: ")
+	if len(why.Chains) == 0 {
+		tw.printf("[bold][red]Not imported by the project anymore (try `govendor remove +unused`)\n")
+		return
+	}
+	tw.printf("[")
+	for _, chain := range why.Chains {
+		for _, node := range chain {
+			tw.printf("\n     %s", node.String())
+		}
+		tw.printf("\n")
+	}
+	tw.printf("   ]\n")
+}
+
+// WriteWhy writes `go mod why` import chains
+func (tw *TextWriter) WriteWhy(why *Why) {
+	if why.Error != "" {
+		tw.printf("[bold][red]Failed to check[reset][red]\n%s", why.Error)
+		return
+	}
+	if len(why.Chains) > 0 {
+		tw.printf("[")
+	} else {
+		tw.printf("[bold][red]Package not needed (try `go mod tidy`)\n")
+	}
+	for _, chain := range why.Chains {
+		for _, node := range chain {
+			tw.printf("\n     %s", node.String())
+		}
+		tw.printf("\n")
+	}
+	if len(why.Chains) > 0 {
+		tw.printf("   ]\n")
+	}
+}
@@ -0,0 +1,253 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/diff"
+)
+
+func TestTextWriterWriteReport(t *testing.T) {
+	r := &Report{
+		Diff: &diff.Diff{
+			NotFound: []*diff.DiffEntry{
+				{
+					ModulePath:   "github.com/hashicorp/hcl",
+					GoModVersion: &diff.Version{Version: "v1.0.0"},
+				},
+			},
+			Matched: []*diff.DiffEntry{
+				{
+					ModulePath:   "golang.org/x/xerrors",
+					GoModVersion: &diff.Version{Version: "v0.0.0-20191011141410-1b5146add898", Revision: "1b5146add898"},
+				},
+			},
+		},
+		Total: 2,
+		Why: map[string]*Why{
+			"github.com/hashicorp/hcl": {
+				Chains: [][]*WhyNode{
+					{
+						{Path: "github.com/hashicorp/terraform"},
+						{Path: "github.com/hashicorp/hcl", Version: "v1.0.0"},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := NewTextWriter(&buf, false).WriteReport(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+github.com/hashicorp/hcl
+ - go modules: v1.0.0
+ - GitHub: https://github.com/hashicorp/hcl/tree/v1.0.0
+ - govendor: not found
+ - go mod why: [
+     github.com/hashicorp/terraform
+     github.com/hashicorp/hcl @ v1.0.0
+   ]
+
+golang.org/x/xerrors ✓
+
+Matched package revisions: 1 of 2.
+1 to check (1 not found and 0 different revs).
+`
+	if output := buf.String(); output != expected {
+		t.Fatalf("Expected:\n%q\ngiven:\n%q", expected, output)
+	}
+	if strings.Contains(buf.String(), "\033[") {
+		t.Fatal("Expected no ANSI codes with colors disabled")
+	}
+}
+
+func TestTextWriterSetDiagnostics(t *testing.T) {
+	r := &Report{
+		Diff: &diff.Diff{
+			NotFound: []*diff.DiffEntry{
+				{
+					ModulePath:   "github.com/hashicorp/hcl",
+					GoModVersion: &diff.Version{Version: "v1.0.0"},
+					Warnings: []*diff.Warning{
+						{Kind: diff.WarningRepositoryArchived, Message: "repository hashicorp/hcl is archived"},
+					},
+				},
+			},
+		},
+		Total: 1,
+	}
+
+	var out, diag bytes.Buffer
+	tw := NewTextWriter(&out, false)
+	tw.SetDiagnostics(&diag, false)
+	err := tw.WriteReport(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(out.String(), "Repository warnings") || strings.Contains(out.String(), "is archived") {
+		t.Fatalf("Expected warnings not to be written to output, given:\n%s", out.String())
+	}
+	expected := `
+Repository warnings:
+
+github.com/hashicorp/hcl
+ - repository hashicorp/hcl is archived (archived)
+1 modules with repository warnings.
+`
+	if output := diag.String(); output != expected {
+		t.Fatalf("Expected:\n%q\ngiven:\n%q", expected, output)
+	}
+}
+
+func TestTextWriterWriteReport_packageGranularity(t *testing.T) {
+	r := &Report{
+		Diff: &diff.Diff{
+			Different: []*diff.DiffEntry{
+				{
+					ModulePath:   "golang.org/x/net",
+					GoModVersion: &diff.Version{Version: "v0.0.0-20180416091243-3b0461eec859", Revision: "3b0461eec859"},
+					GoVendorVersions: []*diff.Version{
+						{
+							Version:  "1c05540f6879653db88113bc4a2b70aec4bd491f",
+							Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f",
+							Packages: []string{"golang.org/x/net/http2"},
+						},
+						{
+							Version:  "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+							Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938",
+							Packages: []string{"golang.org/x/net/context"},
+						},
+					},
+				},
+			},
+			Matched: []*diff.DiffEntry{
+				{
+					ModulePath:   "golang.org/x/xerrors",
+					GoModVersion: &diff.Version{Version: "v0.0.0-20191011141410-1b5146add898", Revision: "1b5146add898"},
+					GoVendorVersions: []*diff.Version{
+						{
+							Version:  "1b5146add8981d58be77b16229c0ff0f8bebd8c1",
+							Revision: "1b5146add8981d58be77b16229c0ff0f8bebd8c1",
+							Packages: []string{"golang.org/x/xerrors"},
+						},
+					},
+				},
+			},
+		},
+		Total:       2,
+		Granularity: GranularityPackage,
+	}
+
+	var buf bytes.Buffer
+	err := NewTextWriter(&buf, false).WriteReport(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+golang.org/x/net
+ - go modules: v0.0.0-20180416091243-3b0461eec859 / 3b0461eec859
+ - govendor packages:
+     ✓ golang.org/x/net/context @ 3b0461eec859c4b73bb64fdc8285971fd33e3938
+     ✗ golang.org/x/net/http2 @ 1c05540f6879653db88113bc4a2b70aec4bd491f
+
+golang.org/x/xerrors ✓
+     ✓ golang.org/x/xerrors @ 1b5146add8981d58be77b16229c0ff0f8bebd8c1
+
+
+Matched package revisions: 1 of 2.
+1 to check (0 not found and 1 different revs).
+Matched vendored packages: 2 of 3.
+`
+	if output := buf.String(); output != expected {
+		t.Fatalf("Expected:\n%q\ngiven:\n%q", expected, output)
+	}
+}
+
+func TestTextWriterWriteDiffEntry_impact(t *testing.T) {
+	de := &diff.DiffEntry{
+		ModulePath:   "example.com/foo",
+		GoModVersion: &diff.Version{Version: "v2.0.0+incompatible"},
+		GoVendorVersions: []*diff.Version{
+			{
+				Version:  "1c05540f6879653db88113bc4a2b70aec4bd491f",
+				Revision: "1c05540f6879653db88113bc4a2b70aec4bd491f",
+				Tag:      "v1.4.0",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	NewTextWriter(&buf, false).WriteDiffEntry(de, nil)
+
+	expected := `
+example.com/foo
+ - go modules: v2.0.0+incompatible
+ - impact: major (v1.4.0 → v2.0.0+incompatible), +incompatible
+ - govendor: [
+       1c05540f6879653db88113bc4a2b70aec4bd491f / v1.4.0
+   ]
+`
+	if output := buf.String(); output != expected {
+		t.Fatalf("Expected:\n%q\ngiven:\n%q", expected, output)
+	}
+
+	buf.Reset()
+	NewTextWriter(&buf, true).WriteDiffEntry(de, nil)
+	if !strings.Contains(buf.String(), "\033[1m\033[31mmajor") {
+		t.Fatalf("Expected major impact in bold red, given:\n%q", buf.String())
+	}
+}
+
+func TestTextWriterWriteReport_duplicateMajors(t *testing.T) {
+	bar := &diff.DiffEntry{
+		ModulePath:   "example.com/bar",
+		GoModVersion: &diff.Version{Version: "v2.0.0+incompatible"},
+		GoVendorVersions: []*diff.Version{
+			{Version: "3b0461eec859c4b73bb64fdc8285971fd33e3938", Revision: "3b0461eec859c4b73bb64fdc8285971fd33e3938"},
+		},
+	}
+	barV2 := &diff.DiffEntry{
+		ModulePath:   "example.com/bar/v2",
+		GoModVersion: &diff.Version{Version: "v2.1.0"},
+	}
+	r := &Report{
+		Diff: &diff.Diff{
+			NotFound:  []*diff.DiffEntry{barV2},
+			Different: []*diff.DiffEntry{bar},
+			DuplicateMajors: []*diff.DuplicateMajor{
+				{
+					Path:           "example.com/bar",
+					ModulePaths:    []string{"example.com/bar", "example.com/bar/v2"},
+					SingleRevision: true,
+					Entries:        []*diff.DiffEntry{bar, barV2},
+				},
+			},
+		},
+		Total: 2,
+	}
+
+	var buf bytes.Buffer
+	err := NewTextWriter(&buf, false).WriteReport(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+
+Duplicate major versions:
+
+example.com/bar (govendor pins a single revision)
+ - example.com/bar @ v2.0.0+incompatible (govendor: 3b0461eec859)
+ - example.com/bar/v2 @ v2.1.0 (govendor: not found)
+`
+	if !strings.Contains(buf.String(), expected) {
+		t.Fatalf("Expected output to contain:\n%q\ngiven:\n%q", expected, buf.String())
+	}
+}
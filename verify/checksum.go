@@ -0,0 +1,225 @@
+package verify
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kardianos/govendor/vendorfile"
+)
+
+// PackageChecksum is a vendored package whose files don't match
+// the checksum recorded in vendor.json
+type PackageChecksum struct {
+	Package  string
+	Expected string
+	Actual   string
+}
+
+// ChecksumReport is the result of validation of vendor.json against the vendor directory
+type ChecksumReport struct {
+	// Mismatched packages were modified (or re-vendored) after vendor.json was written
+	Mismatched []*PackageChecksum
+	// Missing packages are listed in vendor.json, but not in the vendor directory
+	Missing []string
+	// Unrecorded packages have no checksum in vendor.json
+	Unrecorded []string
+	// Orphaned directories contain vendored files of no package listed in vendor.json
+	Orphaned []string
+	// Verified is the number of packages matching their checksum
+	Verified int
+}
+
+// HasProblems returns true if vendor.json is not consistent with the vendor directory
+func (r *ChecksumReport) HasProblems() bool {
+	return len(r.Mismatched) > 0 || len(r.Missing) > 0 || len(r.Unrecorded) > 0 || len(r.Orphaned) > 0
+}
+
+// govendor records this value for packages which were not fetched from a VCS
+const uncommittedChecksum = "uncommitted/version="
+
+// VerifyChecksums recomputes govendor checksums of all packages in vendorDir
+// and compares them with vendor.json
+func VerifyChecksums(vendorDir string, gvFile *vendorfile.File) (*ChecksumReport, error) {
+	ignoreTags := IgnoredTags(gvFile)
+	r := &ChecksumReport{
+		Mismatched: make([]*PackageChecksum, 0),
+		Missing:    make([]string, 0),
+		Unrecorded: make([]string, 0),
+		Orphaned:   make([]string, 0),
+	}
+
+	for _, pkg := range gvFile.Package {
+		if pkg.Remove || pkg.Path == "" {
+			continue
+		}
+		dir := filepath.Join(vendorDir, filepath.FromSlash(pkg.Path))
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			r.Missing = append(r.Missing, pkg.Path)
+			continue
+		}
+		if pkg.ChecksumSHA1 == "" || pkg.ChecksumSHA1 == uncommittedChecksum {
+			r.Unrecorded = append(r.Unrecorded, pkg.Path)
+			continue
+		}
+
+		checksum, err := Checksum(dir, pkg.Path, pkg.Tree, ignoreTags)
+		if err != nil {
+			return nil, err
+		}
+		if checksum != pkg.ChecksumSHA1 {
+			r.Mismatched = append(r.Mismatched, &PackageChecksum{
+				Package:  pkg.Path,
+				Expected: pkg.ChecksumSHA1,
+				Actual:   checksum,
+			})
+			continue
+		}
+		r.Verified++
+	}
+
+	orphaned, err := orphanedDirs(vendorDir, gvFile)
+	if err != nil {
+		return nil, err
+	}
+	r.Orphaned = orphaned
+
+	return r, nil
+}
+
+// Checksum computes checksum of the vendored package the same way
+// govendor does when copying the package into the vendor directory
+func Checksum(dir, pkgPath string, tree bool, ignoreTags []string) (string, error) {
+	h := sha1.New()
+	h.Write([]byte(strings.Trim(pkgPath, "/")))
+	err := walkPackage(dir, "", tree, ignoreTags, func(rel string, fi os.FileInfo) error {
+		if fi.IsDir() {
+			h.Write([]byte(path.Join(pkgPath, rel)))
+			return nil
+		}
+
+		f, err := os.Open(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		h.Write([]byte(fi.Name()))
+		_, err = io.Copy(h, f)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// orphanedDirs returns import paths of directories with files
+// which don't belong to any package listed in vendor.json.
+// Directories with only license files (copied there by govendor) are ignored.
+func orphanedDirs(vendorDir string, gvFile *vendorfile.File) ([]string, error) {
+	orphaned := make([]string, 0)
+	err := filepath.Walk(vendorDir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() {
+			return nil
+		}
+		if p != vendorDir && strings.HasPrefix(fi.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(vendorDir, p)
+		if err != nil {
+			return err
+		}
+		pkgPath := filepath.ToSlash(rel)
+		if p == vendorDir || isVendoredDir(gvFile, pkgPath) {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		infos, err := f.Readdir(-1)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			name := info.Name()
+			if info.IsDir() || strings.HasPrefix(name, ".") || isLicenseFile(name) {
+				continue
+			}
+			orphaned = append(orphaned, pkgPath)
+			break
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(orphaned)
+	return orphaned, nil
+}
+
+// isVendoredDir returns true if files of the directory
+// belong to a package listed in vendor.json
+func isVendoredDir(gvFile *vendorfile.File, dir string) bool {
+	for _, pkg := range gvFile.Package {
+		if pkg.Remove {
+			continue
+		}
+		if dir == pkg.Path {
+			return true
+		}
+		if !strings.HasPrefix(dir, pkg.Path+"/") {
+			continue
+		}
+		if pkg.Tree || (dir == pkg.Path+"/testdata" || strings.HasPrefix(dir, pkg.Path+"/testdata/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// licenseFileNames are (lowercase) parts of file names
+// govendor recognizes as licenses and copies into parent directories
+var licenseFileNames = []struct {
+	text   string
+	prefix bool
+}{
+	{"license", true},
+	{"unlicense", true},
+	{"copying", true},
+	{"copyright", true},
+	{"legal", false},
+	{"notice", false},
+	{"disclaimer", false},
+	{"patent", false},
+	{"third-party", false},
+	{"thirdparty", false},
+}
+
+func isLicenseFile(name string) bool {
+	switch filepath.Ext(name) {
+	case ".go", ".c", ".h", ".cpp", ".hpp":
+		return false
+	}
+	lname := strings.ToLower(name)
+	for _, l := range licenseFileNames {
+		if l.prefix && strings.HasPrefix(lname, l.text) {
+			return true
+		}
+		if !l.prefix && strings.Contains(lname, l.text) {
+			return true
+		}
+	}
+	return false
+}
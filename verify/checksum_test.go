@@ -0,0 +1,122 @@
+package verify
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/kardianos/govendor/vendorfile"
+	"github.com/radeksimko/go-mod-diff/internal/testutil"
+)
+
+func TestChecksum(t *testing.T) {
+	dir := testutil.TempTree(t, map[string]string{
+		"b.go":             "package hcl\n",
+		"a.go":             "package hcl\n// a\n",
+		".travis.yml":      "language: go\n",
+		"testdata/x.hcl":   "x = 1\n",
+		"ast/ast.go":       "package ast\n",
+		"_examples/ex.go":  "package main\n",
+		"parser/parser.go": "package parser\n",
+	})
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		tree       bool
+		ignoreTags []string
+		hashed     string
+	}{
+		{
+			false, nil,
+			"github.com/hashicorp/hcl" +
+				"a.gopackage hcl\n// a\n" + "b.gopackage hcl\n" +
+				"github.com/hashicorp/hcl/testdata" + "x.hclx = 1\n",
+		},
+		{
+			false, []string{"test"},
+			"github.com/hashicorp/hcl" +
+				"a.gopackage hcl\n// a\n" + "b.gopackage hcl\n",
+		},
+		{
+			true, []string{"test"},
+			"github.com/hashicorp/hcl" +
+				"a.gopackage hcl\n// a\n" + "b.gopackage hcl\n" +
+				"github.com/hashicorp/hcl/ast" + "ast.gopackage ast\n" +
+				"github.com/hashicorp/hcl/parser" + "parser.gopackage parser\n",
+		},
+	}
+
+	for i, tc := range testCases {
+		checksum, err := Checksum(dir, "github.com/hashicorp/hcl", tc.tree, tc.ignoreTags)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if expected := sha1Base64(tc.hashed); checksum != expected {
+			t.Fatalf("%d: Expected checksum %q, given: %q", i, expected, checksum)
+		}
+	}
+}
+
+func TestVerifyChecksums(t *testing.T) {
+	dir := testutil.TempTree(t, map[string]string{
+		"github.com/hashicorp/hcl/hcl.go":         "package hcl\n",
+		"github.com/hashicorp/hcl/lex.go":         "package hcl\n// patched\n",
+		"github.com/hashicorp/hcl/LICENSE":        "MPL",
+		"github.com/hashicorp/hcl/hcl/ast/ast.go": "package ast\n",
+		"github.com/hashicorp/local/local.go":     "package local\n",
+		"github.com/hashicorp/unused/unused.go":   "package unused\n",
+		"golang.org/x/net/LICENSE":                "BSD",
+		"golang.org/x/net/PATENTS":                "Google",
+		"golang.org/x/net/context/context.go":     "package context\n",
+	})
+	defer os.RemoveAll(dir)
+
+	gvFile := &vendorfile.File{
+		Ignore: "test",
+		Package: []*vendorfile.Package{
+			{
+				Path:         "github.com/hashicorp/hcl",
+				ChecksumSHA1: sha1Base64("github.com/hashicorp/hcl" + "LICENSEMPL" + "hcl.gopackage hcl\n" + "lex.gopackage hcl\n"),
+			},
+			{
+				Path:         "github.com/hashicorp/hcl/hcl/ast",
+				ChecksumSHA1: sha1Base64("github.com/hashicorp/hcl/hcl/ast" + "ast.gopackage ast\n"),
+			},
+			{Path: "github.com/hashicorp/local", ChecksumSHA1: "uncommitted/version="},
+			{Path: "github.com/hashicorp/deleted", ChecksumSHA1: "2jmj7l5rSw0yVb/vlWAYkK/YBwk="},
+			{
+				Path:         "golang.org/x/net/context",
+				ChecksumSHA1: sha1Base64("golang.org/x/net/context" + "context.gopackage context\n"),
+			},
+		},
+	}
+
+	r, err := VerifyChecksums(dir, gvFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Verified != 2 {
+		t.Fatalf("Expected 2 verified packages, given: %d", r.Verified)
+	}
+	if len(r.Mismatched) != 1 || r.Mismatched[0].Package != "github.com/hashicorp/hcl" {
+		t.Fatalf("Expected hcl to mismatch, given: %#v", r.Mismatched)
+	}
+	if expected := []string{"github.com/hashicorp/deleted"}; !reflect.DeepEqual(r.Missing, expected) {
+		t.Fatalf("Expected missing: %q, given: %q", expected, r.Missing)
+	}
+	if expected := []string{"github.com/hashicorp/local"}; !reflect.DeepEqual(r.Unrecorded, expected) {
+		t.Fatalf("Expected unrecorded: %q, given: %q", expected, r.Unrecorded)
+	}
+	// Directories with only licenses (golang.org/x/net) are not orphaned
+	if expected := []string{"github.com/hashicorp/unused"}; !reflect.DeepEqual(r.Orphaned, expected) {
+		t.Fatalf("Expected orphaned: %q, given: %q", expected, r.Orphaned)
+	}
+}
+
+func sha1Base64(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
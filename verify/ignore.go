@@ -0,0 +1,136 @@
+package verify
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/kardianos/govendor/vendorfile"
+)
+
+// OS and architecture names govendor recognizes in file name suffixes
+var (
+	knownOS   = stringSet("android darwin dragonfly freebsd linux nacl netbsd openbsd plan9 solaris windows")
+	knownArch = stringSet("386 amd64 amd64p32 arm armbe arm64 arm64be ppc64 ppc64le mips mipsle " +
+		"mips64 mips64le mips64p32 mips64p32le ppc s390 s390x sparc sparc64")
+)
+
+// IgnoredTags returns build tags govendor was configured to ignore
+// ("test" ignores tests), ignored package paths are left out
+func IgnoredTags(gvFile *vendorfile.File) []string {
+	tags := make([]string, 0)
+	for _, item := range strings.Fields(strings.Replace(gvFile.Ignore, ",", " ", -1)) {
+		if !strings.Contains(item, "/") {
+			tags = append(tags, item)
+		}
+	}
+	return tags
+}
+
+// ignoredFile returns true if govendor would not vendor the Go file
+// because of its build tags, following govendor's (simplified) evaluation:
+// a file is ignored if it has "+build ignore", if any of its file name tags
+// is ignored, or if every alternative of its build constraints is ignored
+func ignoredFile(path string, ignoreTags []string) bool {
+	if !strings.HasSuffix(path, ".go") {
+		return false
+	}
+	f, _ := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly|parser.ParseComments)
+	if f == nil {
+		return false
+	}
+	pkgName := strings.TrimSuffix(f.Name.Name, "_test")
+	if pkgName == "documentation" {
+		return false
+	}
+
+	fileTags := fileNameTags(path)
+	if f.Name.Name != pkgName {
+		fileTags = append(fileTags, "test")
+	}
+
+	alternatives := make([]string, 0)
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if strings.HasPrefix(c.Text, "// +build ") {
+				alternatives = append(alternatives, strings.Fields(strings.TrimPrefix(c.Text, "// +build "))...)
+			}
+		}
+	}
+	for _, alt := range alternatives {
+		if alt == "ignore" {
+			return true
+		}
+	}
+
+	// File name tags conflicting with build constraints, e.g. foo_linux.go with +build !linux
+	for _, ft := range fileTags {
+		for _, alt := range alternatives {
+			for _, tag := range strings.Split(alt, ",") {
+				if tag == "!"+ft {
+					return true
+				}
+			}
+		}
+	}
+
+	for _, ft := range fileTags {
+		if containsString(ignoreTags, ft) {
+			return true
+		}
+	}
+	if len(alternatives) == 0 || len(ignoreTags) == 0 {
+		return false
+	}
+	for _, alt := range alternatives {
+		if !ignoredAlternative(alt, ignoreTags) {
+			return false
+		}
+	}
+	return true
+}
+
+// ignoredAlternative returns true if any (non-negated) tag
+// of comma-separated alternative is ignored
+func ignoredAlternative(alt string, ignoreTags []string) bool {
+	for _, tag := range strings.Split(alt, ",") {
+		if !strings.HasPrefix(tag, "!") && containsString(ignoreTags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileNameTags returns tags implied by the file name, e.g. test, linux and amd64
+// for foo_linux_amd64_test.go. Like in go/build (goodOSArchFile), only
+// underscore-separated suffixes count, so linux.go implies no tags.
+func fileNameTags(path string) []string {
+	name := strings.TrimSuffix(path[strings.LastIndexAny(path, `/\`)+1:], ".go")
+	i := strings.Index(name, "_")
+	if i < 0 {
+		return nil
+	}
+	parts := strings.Split(name[i:], "_")
+
+	var tags []string
+	if n := len(parts); parts[n-1] == "test" {
+		parts = parts[:n-1]
+		tags = append(tags, "test")
+	}
+	n := len(parts)
+	if n >= 2 && knownOS[parts[n-2]] && knownArch[parts[n-1]] {
+		return append(tags, parts[n-2], parts[n-1])
+	}
+	if n >= 1 && (knownOS[parts[n-1]] || knownArch[parts[n-1]]) {
+		tags = append(tags, parts[n-1])
+	}
+	return tags
+}
+
+func stringSet(list string) map[string]bool {
+	set := make(map[string]bool, 0)
+	for _, s := range strings.Fields(list) {
+		set[s] = true
+	}
+	return set
+}
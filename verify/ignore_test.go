@@ -0,0 +1,90 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kardianos/govendor/vendorfile"
+	"github.com/radeksimko/go-mod-diff/internal/testutil"
+)
+
+func TestIgnoredTags(t *testing.T) {
+	tags := IgnoredTags(&vendorfile.File{Ignore: "test appengine,github.com/hashicorp/hcl/testdata/"})
+	expected := []string{"test", "appengine"}
+	if !reflect.DeepEqual(expected, tags) {
+		t.Fatalf("Expected: %q\ngiven: %q", expected, tags)
+	}
+}
+
+func TestFileNameTags(t *testing.T) {
+	testCases := map[string][]string{
+		"hcl.go":                nil,
+		"linux.go":              nil,
+		"amd64.go":              nil,
+		"linux_test.go":         {"test"},
+		"hcl_linux.go":          {"linux"},
+		"x_linux_amd64.go":      {"linux", "amd64"},
+		"x_linux_amd64_test.go": {"test", "linux", "amd64"},
+		"x/y/z_windows.go":      {"windows"},
+	}
+	for name, expected := range testCases {
+		if tags := fileNameTags(name); !reflect.DeepEqual(expected, tags) {
+			t.Fatalf("%s: Expected %q, given: %q", name, expected, tags)
+		}
+	}
+}
+
+func TestIgnoredFile(t *testing.T) {
+	dir := testutil.TempTree(t, map[string]string{
+		"hcl.go":            "package hcl\n",
+		"hcl_test.go":       "package hcl\n",
+		"external.go":       "package hcl_test\n",
+		"appengine.go":      "// +build appengine\n\npackage hcl\n",
+		"not_appengine.go":  "// +build !appengine\n\npackage hcl\n",
+		"either.go":         "// +build appengine linux\n\npackage hcl\n",
+		"both.go":           "// +build appengine,linux\n\npackage hcl\n",
+		"gen.go":            "// +build ignore\n\npackage main\n",
+		"conflict_linux.go": "// +build !linux\n\npackage hcl\n",
+		"hcl_windows.go":    "package hcl\n",
+		"linux.go":          "package hcl\n",
+		"amd64.go":          "package hcl\n",
+		"x_linux_amd64.go":  "package hcl\n",
+		"README.md":         "// +build appengine\n",
+		"doc_appengine.go":  "package documentation\n",
+	})
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		name       string
+		ignoreTags []string
+		expected   bool
+	}{
+		{"hcl.go", []string{"test", "appengine"}, false},
+		{"hcl_test.go", []string{"test", "appengine"}, true},
+		{"hcl_test.go", nil, false},
+		{"external.go", []string{"test"}, true},
+		{"appengine.go", []string{"test", "appengine"}, true},
+		{"appengine.go", []string{"test"}, false},
+		{"not_appengine.go", []string{"appengine"}, false},
+		{"either.go", []string{"appengine"}, false},
+		{"both.go", []string{"appengine"}, true},
+		{"gen.go", nil, true},
+		{"conflict_linux.go", nil, true},
+		{"hcl_windows.go", []string{"windows"}, true},
+		{"linux.go", []string{"linux"}, false},
+		{"amd64.go", []string{"amd64"}, false},
+		{"x_linux_amd64.go", []string{"linux"}, true},
+		{"x_linux_amd64.go", []string{"amd64"}, true},
+		{"x_linux_amd64.go", []string{"windows"}, false},
+		{"README.md", []string{"appengine"}, false},
+		{"doc_appengine.go", []string{"appengine"}, false},
+	}
+	for _, tc := range testCases {
+		ignored := ignoredFile(filepath.Join(dir, tc.name), tc.ignoreTags)
+		if ignored != tc.expected {
+			t.Fatalf("%s (ignoring %q): expected %t, given: %t", tc.name, tc.ignoreTags, tc.expected, ignored)
+		}
+	}
+}
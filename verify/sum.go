@@ -0,0 +1,168 @@
+package verify
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/radeksimko/go-mod-diff/gomod"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+type SumStatus string
+
+const (
+	SumMismatch SumStatus = "mismatch"
+	SumMissing  SumStatus = "missing"
+	// SumExtra is a hash of required module at version other than the required one
+	SumExtra SumStatus = "extra"
+	// SumUnavailable is a hash which can't be verified because
+	// the module version is not in the module cache
+	SumUnavailable SumStatus = "unavailable"
+)
+
+// SumDifference is a go.sum entry which doesn't match the module cache
+type SumDifference struct {
+	Module module.Version
+	// GoMod is true for hashes of the go.mod file only
+	GoMod  bool
+	Status SumStatus
+	// Hash is the hash in go.sum, empty if missing
+	Hash string
+	// Actual is the hash computed from the module cache, empty if unavailable
+	Actual string
+}
+
+func (sd *SumDifference) String() string {
+	version := sd.Module.Version
+	if sd.GoMod {
+		version += "/go.mod"
+	}
+	output := fmt.Sprintf("%s %s", sd.Module.Path, version)
+	switch sd.Status {
+	case SumMismatch:
+		output += fmt.Sprintf(" (go.sum %s, module cache %s)", sd.Hash, sd.Actual)
+	case SumMissing:
+		if sd.Actual != "" {
+			output += " " + sd.Actual
+		}
+	case SumExtra, SumUnavailable:
+		output += " " + sd.Hash
+	}
+	return output
+}
+
+// SumReport is the result of verification of go.sum against the module cache
+type SumReport struct {
+	Differences []*SumDifference
+	// Verified is the number of go.sum entries matching the module cache
+	Verified int
+}
+
+// VerifyGoSum computes h1: hashes of modules go.mod requires (after replacements)
+// and of their go.mod files from the module cache and compares them with go.sum.
+// Module versions missing in the cache are not downloaded.
+func VerifyGoSum(goModFile *modfile.File, sums []*gomod.SumEntry, cache *gomod.ModuleCache) (*SumReport, error) {
+	r := &SumReport{
+		Differences: make([]*SumDifference, 0),
+	}
+
+	required := make(map[string]module.Version, len(goModFile.Require))
+	for _, req := range goModFile.Require {
+		mv := req.Mod
+		if rep := gomod.FindReplace(goModFile, mv); rep != nil {
+			// Replacements with local directories have no hashes
+			if rep.New.Version == "" {
+				continue
+			}
+			mv = rep.New
+		}
+		required[mv.Path] = mv
+
+		for _, goMod := range []bool{false, true} {
+			var hash string
+			for _, entry := range sums {
+				if entry.Module == mv && entry.GoMod == goMod {
+					hash = entry.Hash
+					break
+				}
+			}
+
+			actual, err := cachedHash(cache, mv, goMod)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to compute hash of %s: %s", mv, err)
+			}
+
+			sd := &SumDifference{Module: mv, GoMod: goMod, Hash: hash, Actual: actual}
+			switch {
+			case hash == "":
+				sd.Status = SumMissing
+			case actual == "":
+				sd.Status = SumUnavailable
+			case hash != actual:
+				sd.Status = SumMismatch
+			default:
+				r.Verified++
+				continue
+			}
+			r.Differences = append(r.Differences, sd)
+		}
+	}
+
+	// Hashes of go.mod files of other versions are expected
+	// as go.mod files of all versions in the module graph are read
+	for _, entry := range sums {
+		mv, ok := required[entry.Module.Path]
+		if !ok || entry.GoMod || entry.Module.Version == mv.Version {
+			continue
+		}
+		r.Differences = append(r.Differences, &SumDifference{
+			Module: entry.Module,
+			Status: SumExtra,
+			Hash:   entry.Hash,
+		})
+	}
+
+	sort.SliceStable(r.Differences, func(i, j int) bool {
+		return r.Differences[i].Module.Path < r.Differences[j].Module.Path
+	})
+	return r, nil
+}
+
+// cachedHash computes h1: hash of the module version (or its go.mod file)
+// from the module cache, returning empty string if it's not cached
+func cachedHash(cache *gomod.ModuleCache, mv module.Version, goMod bool) (string, error) {
+	if goMod {
+		modFile, err := cache.DownloadFile(mv, ".mod")
+		if err != nil {
+			return "", err
+		}
+		if _, err := os.Stat(modFile); os.IsNotExist(err) {
+			return "", nil
+		}
+		return dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+			return os.Open(modFile)
+		})
+	}
+
+	dir, err := cache.ModuleDir(mv)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return dirhash.HashDir(dir, mv.String(), dirhash.Hash1)
+	}
+
+	// Module may be downloaded, but not extracted
+	zipFile, err := cache.DownloadFile(mv, ".zip")
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(zipFile); os.IsNotExist(err) {
+		return "", nil
+	}
+	return dirhash.HashZip(zipFile, dirhash.Hash1)
+}
@@ -0,0 +1,79 @@
+package verify
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/gomod"
+	"github.com/radeksimko/go-mod-diff/internal/testutil"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+func TestVerifyGoSum(t *testing.T) {
+	dir := testutil.TempTree(t, map[string]string{
+		"example.com/a@v1.0.0/go.mod":                    "module example.com/a\n",
+		"example.com/a@v1.0.0/a.go":                      "package a\n",
+		"cache/download/example.com/a/@v/v1.0.0.mod":     "module example.com/a\n",
+		"example.com/fork@v1.1.0/go.mod":                 "module example.com/a\n",
+		"example.com/fork@v1.1.0/a.go":                   "package a\n// patched\n",
+		"cache/download/example.com/fork/@v/v1.1.0.mod":  "module example.com/a\n",
+		"cache/download/example.com/nozip/@v/v1.0.0.mod": "module example.com/nozip\n",
+	})
+	defer os.RemoveAll(dir)
+
+	goModFile, err := modfile.Parse("go.mod", []byte(`module example.com/proj
+
+require (
+	example.com/a v1.0.0
+	example.com/b v1.0.0
+	example.com/local v1.0.0
+	example.com/nozip v1.0.0
+)
+
+replace example.com/b => example.com/fork v1.1.0
+
+replace example.com/local => ../local
+`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		hashA      = "h1:WKGCEXVonIBzf8Rfxa1txNoZf8hXmk6l0CpNI8zjCG0="
+		hashAGoMod = "h1:NeOsx/KTizj35klXP3wYh3O0751aAtYrRoX+a6YAye8="
+	)
+	sums := []*gomod.SumEntry{
+		{Module: module.Version{Path: "example.com/a", Version: "v1.0.0"}, Hash: hashA},
+		{Module: module.Version{Path: "example.com/a", Version: "v1.0.0"}, GoMod: true, Hash: hashAGoMod},
+		{Module: module.Version{Path: "example.com/a", Version: "v0.9.0"}, Hash: "h1:old"},
+		{Module: module.Version{Path: "example.com/a", Version: "v0.9.0"}, GoMod: true, Hash: "h1:oldgomod"},
+		{Module: module.Version{Path: "example.com/fork", Version: "v1.1.0"}, Hash: hashA},
+		{Module: module.Version{Path: "example.com/fork", Version: "v1.1.0"}, GoMod: true, Hash: hashAGoMod},
+		{Module: module.Version{Path: "example.com/nozip", Version: "v1.0.0"}, Hash: "h1:nozip"},
+	}
+
+	r, err := VerifyGoSum(goModFile, sums, &gomod.ModuleCache{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Verified != 3 {
+		t.Fatalf("Expected 3 verified hashes, given: %d", r.Verified)
+	}
+	expected := []string{
+		"extra: example.com/a v0.9.0 h1:old",
+		"mismatch: example.com/fork v1.1.0 (go.sum h1:WKGCEXVonIBzf8Rfxa1txNoZf8hXmk6l0CpNI8zjCG0=, " +
+			"module cache h1:b0mpVKYx3LJ5MPjIwbotGNt9rBeVa+qu+AK+PCc5pLw=)",
+		"unavailable: example.com/nozip v1.0.0 h1:nozip",
+		"missing: example.com/nozip v1.0.0/go.mod h1:A3qN0CvTaxmUFSqJiNi2POEuQ2ar8sJtjdn4DeQd5gU=",
+	}
+	given := make([]string, 0)
+	for _, sd := range r.Differences {
+		given = append(given, string(sd.Status)+": "+sd.String())
+	}
+	if !reflect.DeepEqual(expected, given) {
+		t.Fatalf("Expected:\n%q\ngiven:\n%q", expected, given)
+	}
+}
@@ -0,0 +1,250 @@
+package verify
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kardianos/govendor/vendorfile"
+	"github.com/radeksimko/go-mod-diff/gomod"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+type FileStatus string
+
+const (
+	FileModified     FileStatus = "modified"
+	FileOnlyVendored FileStatus = "only_vendored"
+	FileOnlyInModule FileStatus = "only_in_module"
+)
+
+// FileDifference is a file which differs between vendored package
+// and the same package in the module
+type FileDifference struct {
+	// Path is slash-separated path relative to the package directory
+	Path   string
+	Status FileStatus
+}
+
+func (fd *FileDifference) String() string {
+	return fmt.Sprintf("%s: %s", strings.Replace(string(fd.Status), "_", " ", -1), fd.Path)
+}
+
+// PackageTree is the result of comparison of vendored package
+// with the module version go.mod pulls in
+type PackageTree struct {
+	Package     string
+	Module      module.Version
+	Differences []*FileDifference
+	Error       error
+}
+
+// PackageModules returns module versions which go.mod pulls in (after replacements)
+// for vendored packages. Packages not provided by any required module are omitted.
+func PackageModules(gvFile *vendorfile.File, goModFile *modfile.File) map[string]module.Version {
+	required := gomod.RequiredPaths(goModFile)
+	versions := make(map[string]module.Version, len(goModFile.Require))
+	for _, r := range goModFile.Require {
+		versions[r.Mod.Path] = r.Mod
+		if rep := gomod.FindReplace(goModFile, r.Mod); rep != nil {
+			versions[r.Mod.Path] = rep.New
+		}
+	}
+
+	modules := make(map[string]module.Version, len(gvFile.Package))
+	for _, pkg := range gvFile.Package {
+		mod := gomod.MatchModule(required, pkg.Path)
+		if mod != "" {
+			modules[pkg.Path] = versions[mod]
+		}
+	}
+	return modules
+}
+
+// VendorTree compares every vendored package with the package in the module
+// go.mod pulls in. moduleDirs maps module versions (path@version) to directories
+// with their contents, e.g. in the module cache.
+func VendorTree(vendorDir string, gvFile *vendorfile.File, goModFile *modfile.File,
+	moduleDirs map[string]string) []*PackageTree {
+
+	ignoreTags := IgnoredTags(gvFile)
+	required := gomod.RequiredPaths(goModFile)
+	modules := PackageModules(gvFile, goModFile)
+
+	trees := make([]*PackageTree, 0)
+	for _, pkg := range gvFile.Package {
+		mv, ok := modules[pkg.Path]
+		if !ok {
+			continue
+		}
+		pt := &PackageTree{
+			Package: pkg.Path,
+			Module:  mv,
+		}
+		trees = append(trees, pt)
+
+		modDir, ok := moduleDirs[mv.String()]
+		if !ok {
+			pt.Error = fmt.Errorf("Contents of %s are not available", mv)
+			continue
+		}
+
+		// Replacements may provide the package under a different module path
+		rel := strings.TrimPrefix(pkg.Path, gomod.MatchModule(required, pkg.Path))
+		pt.Differences, pt.Error = CompareTree(
+			filepath.Join(vendorDir, filepath.FromSlash(pkg.Path)),
+			filepath.Join(modDir, filepath.FromSlash(rel)),
+			pkg.Tree, ignoreTags)
+	}
+	return trees
+}
+
+// CompareTree compares files of a vendored package with files of the package
+// in the module, following govendor rules about which files are vendored
+// (including files with ignored build tags)
+func CompareTree(vendorPkgDir, modulePkgDir string, tree bool, ignoreTags []string) ([]*FileDifference, error) {
+	vendored, err := packageFiles(vendorPkgDir, tree, ignoreTags)
+	if err != nil {
+		return nil, err
+	}
+	inModule, err := packageFiles(modulePkgDir, tree, ignoreTags)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	diffs := make([]*FileDifference, 0)
+	for _, f := range vendored {
+		if !containsString(inModule, f) {
+			diffs = append(diffs, &FileDifference{Path: f, Status: FileOnlyVendored})
+			continue
+		}
+		same, err := sameContent(
+			filepath.Join(vendorPkgDir, filepath.FromSlash(f)),
+			filepath.Join(modulePkgDir, filepath.FromSlash(f)))
+		if err != nil {
+			return nil, err
+		}
+		if !same {
+			diffs = append(diffs, &FileDifference{Path: f, Status: FileModified})
+		}
+	}
+	for _, f := range inModule {
+		// Only source files are expected to be vendored
+		if !strings.HasSuffix(f, ".go") {
+			continue
+		}
+		if !containsString(vendored, f) {
+			diffs = append(diffs, &FileDifference{Path: f, Status: FileOnlyInModule})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs, nil
+}
+
+// packageFiles lists files govendor would vendor from the package directory
+func packageFiles(dir string, tree bool, ignoreTags []string) ([]string, error) {
+	files := make([]string, 0)
+	err := walkPackage(dir, "", tree, ignoreTags, func(rel string, fi os.FileInfo) error {
+		if !fi.IsDir() {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// walkPackage calls fn for every file govendor would vendor from dir
+// and every directory before descending into it, in the order
+// govendor hashes them (files first, then directories)
+func walkPackage(dir, rel string, tree bool, ignoreTags []string, fn func(string, os.FileInfo) error) error {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		a, b := infos[i], infos[j]
+		if a.IsDir() == b.IsDir() {
+			return a.Name() < b.Name()
+		}
+		return !a.IsDir()
+	})
+
+	for _, fi := range infos {
+		name := fi.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		fileRel := name
+		if rel != "" {
+			fileRel = rel + "/" + name
+		}
+
+		if fi.IsDir() {
+			isTestdata := name == "testdata"
+			if (!tree && !isTestdata) || strings.HasPrefix(name, "_") {
+				continue
+			}
+			if containsString(ignoreTags, "test") && (isTestdata || strings.HasSuffix(name, "_test")) {
+				continue
+			}
+			err := fn(fileRel, fi)
+			if err != nil {
+				return err
+			}
+			// Everything below testdata is vendored too
+			err = walkPackage(filepath.Join(dir, name), fileRel, true, ignoreTags, fn)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Build tags are not checked below testdata
+		if !underTestdata(rel) && ignoredFile(filepath.Join(dir, name), ignoreTags) {
+			continue
+		}
+		err := fn(fileRel, fi)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func underTestdata(rel string) bool {
+	for _, dir := range strings.Split(rel, "/") {
+		if dir == "testdata" {
+			return true
+		}
+	}
+	return false
+}
+
+func sameContent(a, b string) (bool, error) {
+	ac, err := ioutil.ReadFile(a)
+	if err != nil {
+		return false, err
+	}
+	bc, err := ioutil.ReadFile(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(ac, bc), nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
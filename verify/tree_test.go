@@ -0,0 +1,94 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/radeksimko/go-mod-diff/internal/testutil"
+)
+
+func TestCompareTree(t *testing.T) {
+	dir := testutil.TempTree(t, map[string]string{
+		"vendor/hcl.go":              "package hcl\n// patched\n",
+		"vendor/lex.go":              "package hcl\n",
+		"vendor/local.go":            "package hcl\n",
+		"vendor/LICENSE":             "MPL",
+		"vendor/testdata/a.hcl":      "a = 1\n",
+		"vendor/ast/ast.go":          "package ast\n",
+		"module/hcl.go":              "package hcl\n",
+		"module/lex.go":              "package hcl\n",
+		"module/parse.go":            "package hcl\n",
+		"module/hcl_test.go":         "package hcl\n",
+		"module/appengine.go":        "// +build appengine\n\npackage hcl\n",
+		"module/README.md":           "# HCL\n",
+		"module/LICENSE":             "MPL",
+		"module/testdata/a.hcl":      "a = 1\n",
+		"module/ast/ast.go":          "package ast\n// changed\n",
+		"module/.github/workflow.go": "package workflow\n",
+	})
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		tree       bool
+		ignoreTags []string
+		expected   []*FileDifference
+	}{
+		{
+			false, nil,
+			[]*FileDifference{
+				{Path: "appengine.go", Status: FileOnlyInModule},
+				{Path: "hcl.go", Status: FileModified},
+				{Path: "hcl_test.go", Status: FileOnlyInModule},
+				{Path: "local.go", Status: FileOnlyVendored},
+				{Path: "parse.go", Status: FileOnlyInModule},
+			},
+		},
+		{
+			false, []string{"test"},
+			[]*FileDifference{
+				{Path: "appengine.go", Status: FileOnlyInModule},
+				{Path: "hcl.go", Status: FileModified},
+				{Path: "local.go", Status: FileOnlyVendored},
+				{Path: "parse.go", Status: FileOnlyInModule},
+			},
+		},
+		{
+			true, []string{"test", "appengine"},
+			[]*FileDifference{
+				{Path: "ast/ast.go", Status: FileModified},
+				{Path: "hcl.go", Status: FileModified},
+				{Path: "local.go", Status: FileOnlyVendored},
+				{Path: "parse.go", Status: FileOnlyInModule},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		diffs, err := CompareTree(filepath.Join(dir, "vendor"), filepath.Join(dir, "module"),
+			tc.tree, tc.ignoreTags)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if !reflect.DeepEqual(diffs, tc.expected) {
+			t.Fatalf("%d: Expected: %q\ngiven: %q", i, tc.expected, diffs)
+		}
+	}
+}
+
+func TestCompareTree_packageMissingInModule(t *testing.T) {
+	dir := testutil.TempTree(t, map[string]string{
+		"hcl.go": "package hcl\n",
+	})
+	defer os.RemoveAll(dir)
+
+	diffs, err := CompareTree(dir, filepath.Join(dir, "missing"), false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*FileDifference{{Path: "hcl.go", Status: FileOnlyVendored}}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Fatalf("Expected: %q\ngiven: %q", expected, diffs)
+	}
+}